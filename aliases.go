@@ -0,0 +1,449 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	userConfigFileName = "config.json"
+	// maxExpansionDepth is how deep aliases and macros may expand to one
+	// another, so one expanding to itself stops
+	maxExpansionDepth = 10
+)
+
+// userConfig holds the aliases and macros defined by the player
+type userConfig struct {
+	// Aliases map an alias to the command line it stands for
+	Aliases map[string]string `json:"aliases"`
+	// Macros map a macro to the command lines it runs, with $1, $2... for
+	// its arguments
+	Macros map[string][]string `json:"macros"`
+}
+
+// userSettings are the aliases and macros in use, loaded from the
+// configuration file
+var userSettings = userConfig{Aliases: map[string]string{}, Macros: map[string][]string{}}
+
+// userConfigFilePath returns the location of the configuration file
+func userConfigFilePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, userConfigFileName), nil
+}
+
+// loadUserConfig reads the configuration file at path. A missing file has
+// no aliases and no macros. Aliases and macros whose name is taken by a
+// command or built-in alias are left out and reported in the error, along
+// with the rest of the configuration.
+func loadUserConfig(path string) (userConfig, error) {
+	settings := userConfig{}
+	err := readJSONFile(path, &settings)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return userConfig{Aliases: map[string]string{}, Macros: map[string][]string{}}, err
+	}
+	if settings.Aliases == nil {
+		settings.Aliases = map[string]string{}
+	}
+	if settings.Macros == nil {
+		settings.Macros = map[string][]string{}
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(settings.Aliases)) {
+		if err := checkNewName(name); err != nil {
+			errs = append(errs, fmt.Errorf("alias ignored: %w", err))
+			delete(settings.Aliases, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(settings.Macros)) {
+		if err := checkNewName(name); err != nil {
+			errs = append(errs, fmt.Errorf("macro ignored: %w", err))
+			delete(settings.Macros, name)
+		}
+	}
+	return settings, errors.Join(errs...)
+}
+
+// storeUserConfig writes the aliases and macros in use to the configuration file
+func storeUserConfig() error {
+	path, err := userConfigFilePath()
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, userSettings)
+}
+
+// lookupCommand returns the command called name, or having name as a
+// built-in alias
+func lookupCommand(name string) (cliCommand, bool) {
+	if command, ok := commandLists[name]; ok {
+		return command, true
+	}
+	for _, command := range commandLists {
+		if slices.Contains(command.aliases, name) {
+			return command, true
+		}
+	}
+	return cliCommand{}, false
+}
+
+// unknownCommandError reports that no command is called name, suggesting
+// close names
+func unknownCommandError(name string) error {
+	names := commandNames(nil)
+	names = append(names, slices.Collect(maps.Keys(userSettings.Aliases))...)
+	names = append(names, slices.Collect(maps.Keys(userSettings.Macros))...)
+
+	err := fmt.Errorf("unknown command %s", name)
+	if suggestions := suggestNames(names, name); len(suggestions) > 0 {
+		return fmt.Errorf("%w. Did you mean %s?", err, strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("%w. See: help", err)
+}
+
+// commandRest returns what follows the first word of line, as typed
+func commandRest(line string) string {
+	line = strings.TrimSpace(line)
+	end := strings.IndexFunc(line, unicode.IsSpace)
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(line[end:])
+}
+
+// runCommand runs a command line: a command, or one of its built-in
+// aliases, runs with the arguments it accepts, a user alias is replaced by
+// what it stands for, and a macro runs its commands in turn, stopping at
+// the first error. Commands come first so aliases and macros never shadow
+// them. depth counts the aliases and macros expanded to get to line.
+func runCommand(client *pokeapi.Client, config *pokeapi.Config, line string, depth int) error {
+	words, err := splitArgs(line)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	name := strings.ToLower(words[0])
+
+	if command, ok := lookupCommand(name); ok {
+		return runBuiltin(client, config, command, line, words[1:])
+	}
+
+	_, isAlias := userSettings.Aliases[name]
+	_, isMacro := userSettings.Macros[name]
+	if (isAlias || isMacro) && depth >= maxExpansionDepth {
+		return fmt.Errorf("%s: aliases and macros expand more than %d times, is one expanding to itself?", name, maxExpansionDepth)
+	}
+
+	if isAlias {
+		return runCommand(client, config, userSettings.Aliases[name]+" "+commandRest(line), depth+1)
+	}
+
+	if isMacro {
+		lines, err := expandMacro(name, userSettings.Macros[name], words[1:])
+		if err != nil {
+			return err
+		}
+		for _, macroLine := range lines {
+			if err := runCommand(client, config, macroLine, depth+1); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	return unknownCommandError(name)
+}
+
+// runBuiltin runs command with the words following its name in line
+func runBuiltin(client *pokeapi.Client, config *pokeapi.Config, command cliCommand, line string, words []string) error {
+	// Only battle actions are allowed while a battle is going on
+	if currentBattle != nil && !battleCommands[command.name] {
+		return fmt.Errorf("you are in a battle! Use fight, switch, bag or run")
+	}
+
+	if command.rawArgs {
+		var args []string
+		if rest := commandRest(line); rest != "" {
+			args = []string{rest}
+		}
+		return command.callback(client, config, args, nil)
+	}
+
+	args, flags, err := parseArgs(command, words)
+	if err != nil {
+		return err
+	}
+	return command.callback(client, config, args, flags)
+}
+
+// expandMacro returns the command lines of the macro called name with $1,
+// $2... replaced by its arguments and $@ by all of them
+func expandMacro(name string, body []string, args []string) ([]string, error) {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteArg(arg)
+	}
+
+	used, usesAll := 0, false
+	lines := make([]string, 0, len(body))
+	for _, line := range body {
+		var expanded strings.Builder
+		for i := 0; i < len(line); i++ {
+			if line[i] != '$' || i+1 == len(line) {
+				expanded.WriteByte(line[i])
+				continue
+			}
+			if line[i+1] == '@' {
+				expanded.WriteString(strings.Join(quoted, " "))
+				usesAll = true
+				i++
+				continue
+			}
+
+			end := i + 1
+			for end < len(line) && line[end] >= '0' && line[end] <= '9' {
+				end++
+			}
+			n, err := strconv.Atoi(line[i+1 : end])
+			if err != nil || n == 0 {
+				expanded.WriteByte(line[i])
+				continue
+			}
+			if n > len(args) {
+				return nil, fmt.Errorf("missing arguments: %s uses $%d, got %d", name, n, len(args))
+			}
+			expanded.WriteString(quoted[n-1])
+			used = max(used, n)
+			i = end - 1
+		}
+		lines = append(lines, expanded.String())
+	}
+
+	if !usesAll && len(args) > used {
+		return nil, fmt.Errorf("too many arguments: %s uses %d, got %d", name, used, len(args))
+	}
+	return lines, nil
+}
+
+// quoteArg quotes arg, if needed, so splitArgs gives it back as one word
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsFunc(arg, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`'"\;$`, r)
+	}) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// checkNewName returns an error if name can't be given to a new alias or
+// macro: it must be a single word that isn't a command or built-in alias
+func checkNewName(name string) error {
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	}) {
+		return fmt.Errorf("%q is not a valid name: use letters, digits, - and _", name)
+	}
+	if command, ok := lookupCommand(name); ok {
+		if command.name == name {
+			return fmt.Errorf("%s is already a command", name)
+		}
+		return fmt.Errorf("%s is already an alias of %s", name, command.name)
+	}
+	return nil
+}
+
+// checkRunnable returns an error if the first word of line is not a
+// command, an alias or a macro
+func checkRunnable(line string) error {
+	words, err := splitArgs(line)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("nothing to run")
+	}
+
+	name := strings.ToLower(words[0])
+	_, isAlias := userSettings.Aliases[name]
+	_, isMacro := userSettings.Macros[name]
+	if _, isCommand := lookupCommand(name); !isCommand && !isAlias && !isMacro {
+		return unknownCommandError(name)
+	}
+	return nil
+}
+
+// commandAlias lists the aliases, shows one, or defines one standing for a
+// command line. Usage: alias [name[=command]]
+func commandAlias(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		fmt.Println("Built-in aliases:")
+		for _, name := range slices.Sorted(maps.Keys(commandLists)) {
+			for _, alias := range commandLists[name].aliases {
+				fmt.Printf(" %s=%s\n", alias, name)
+			}
+		}
+		if len(userSettings.Aliases) == 0 {
+			fmt.Println("Define your own with: alias <name>=<command>")
+			return nil
+		}
+		fmt.Println("Your aliases:")
+		for _, name := range slices.Sorted(maps.Keys(userSettings.Aliases)) {
+			fmt.Printf(" %s=%s\n", name, userSettings.Aliases[name])
+		}
+		return nil
+	}
+
+	name, value, isDefinition := strings.Cut(args[0], "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !isDefinition {
+		if value, ok := userSettings.Aliases[name]; ok {
+			fmt.Printf("%s=%s\n", name, value)
+			return nil
+		}
+		if command, ok := lookupCommand(name); ok && command.name != name {
+			fmt.Printf("%s=%s (built-in)\n", name, command.name)
+			return nil
+		}
+		return fmt.Errorf("there is no alias named %s", name)
+	}
+
+	// A value quoted as a whole is unquoted, e.g. alias gb='buy great-ball'
+	value = strings.TrimSpace(value)
+	if words, err := splitArgs(value); err == nil && len(words) == 1 {
+		value = words[0]
+	}
+
+	if err := checkNewName(name); err != nil {
+		return err
+	}
+	if _, ok := userSettings.Macros[name]; ok {
+		return fmt.Errorf("%s is already a macro", name)
+	}
+	if err := checkRunnable(value); err != nil {
+		return err
+	}
+
+	userSettings.Aliases[name] = value
+	if err := storeUserConfig(); err != nil {
+		return fmt.Errorf("saving the alias: %w", err)
+	}
+	fmt.Printf("%s now runs: %s\n", name, value)
+	return nil
+}
+
+// commandUnalias removes an alias. Usage: unalias <name>
+func commandUnalias(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	name := args[0]
+	if _, ok := userSettings.Aliases[name]; !ok {
+		return fmt.Errorf("there is no alias named %s", name)
+	}
+
+	delete(userSettings.Aliases, name)
+	if err := storeUserConfig(); err != nil {
+		return fmt.Errorf("saving the aliases: %w", err)
+	}
+	fmt.Printf("Removed the alias %s\n", name)
+	return nil
+}
+
+// commandMacro lists the macros, shows one, or defines one running command
+// lines separated by semicolons. Usage: macro [name] [= command; command...]
+func commandMacro(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		if len(userSettings.Macros) == 0 {
+			fmt.Println("No macros yet. Define one with: macro <name> = <command>; <command>...")
+			return nil
+		}
+		for _, name := range slices.Sorted(maps.Keys(userSettings.Macros)) {
+			fmt.Printf(" %s = %s\n", name, strings.Join(userSettings.Macros[name], "; "))
+		}
+		return nil
+	}
+
+	name, body, isDefinition := strings.Cut(args[0], "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !isDefinition {
+		lines, ok := userSettings.Macros[name]
+		if !ok {
+			return fmt.Errorf("there is no macro named %s", name)
+		}
+		fmt.Printf("%s = %s\n", name, strings.Join(lines, "; "))
+		return nil
+	}
+
+	if err := checkNewName(name); err != nil {
+		return err
+	}
+	if _, ok := userSettings.Aliases[name]; ok {
+		return fmt.Errorf("%s is already an alias", name)
+	}
+
+	lines, err := splitCommands(body)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("a macro needs commands. Usage: %s", commandLists["macro"].usageLine())
+	}
+	for _, line := range lines {
+		// A macro running itself would never stop
+		if words, err := splitArgs(line); err == nil && len(words) > 0 && strings.ToLower(words[0]) == name {
+			return fmt.Errorf("%s can't run itself", name)
+		}
+		if err := checkRunnable(line); err != nil {
+			return err
+		}
+	}
+
+	userSettings.Macros[name] = lines
+	if err := storeUserConfig(); err != nil {
+		return fmt.Errorf("saving the macro: %w", err)
+	}
+	fmt.Printf("%s now runs: %s\n", name, strings.Join(lines, "; "))
+	return nil
+}
+
+// commandUnmacro removes a macro. Usage: unmacro <name>
+func commandUnmacro(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	name := args[0]
+	if _, ok := userSettings.Macros[name]; !ok {
+		return fmt.Errorf("there is no macro named %s", name)
+	}
+
+	delete(userSettings.Macros, name)
+	if err := storeUserConfig(); err != nil {
+		return fmt.Errorf("saving the macros: %w", err)
+	}
+	fmt.Printf("Removed the macro %s\n", name)
+	return nil
+}
+
+// userNames returns the names of the aliases and macros
+func userNames(config *pokeapi.Config) []string {
+	names := slices.Collect(maps.Keys(userSettings.Aliases))
+	return append(names, slices.Collect(maps.Keys(userSettings.Macros))...)
+}
+
+// aliasNames returns the names of the aliases
+func aliasNames(config *pokeapi.Config) []string {
+	return slices.Collect(maps.Keys(userSettings.Aliases))
+}
+
+// macroNames returns the names of the macros
+func macroNames(config *pokeapi.Config) []string {
+	return slices.Collect(maps.Keys(userSettings.Macros))
+}
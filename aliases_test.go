@@ -0,0 +1,156 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestExpandMacro(t *testing.T) {
+	body := []string{"explore $1", "catch $2"}
+
+	lines, err := expandMacro("hunt", body, []string{"viridian-forest-area", "Mr. Mime"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"explore viridian-forest-area", "catch 'Mr. Mime'"}; !slices.Equal(lines, expected) {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+
+	if _, err := expandMacro("hunt", body, []string{"viridian-forest-area"}); err == nil {
+		t.Error("expected an error for a missing argument")
+	}
+	if _, err := expandMacro("hunt", body, []string{"a", "b", "c"}); err == nil {
+		t.Error("expected an error for an extra argument")
+	}
+
+	lines, err = expandMacro("all", []string{"buy $@", "money $"}, []string{"great", "ball", "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"buy great ball 3", "money $"}; !slices.Equal(lines, expected) {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	for _, arg := range []string{"pikachu", "Mr. Mime", "it's", `a "b"`, "a;b", "$1", ""} {
+		words, err := splitArgs(quoteArg(arg))
+		if err != nil || len(words) != 1 || words[0] != arg {
+			t.Errorf("%q: quoted as %s, split back to %q (%v)", arg, quoteArg(arg), words, err)
+		}
+	}
+}
+
+func TestRunCommandAliasesAndMacros(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var ran []string
+	record := func(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+		ran = append(ran, args...)
+		return nil
+	}
+	all := commands()
+	originalCommands, originalSettings := commandLists, userSettings
+	t.Cleanup(func() { commandLists, userSettings = originalCommands, originalSettings })
+	commandLists = map[string]cliCommand{
+		"catch":   {name: "catch", aliases: []string{"c"}, maxArgs: -1, callback: record},
+		"explore": {name: "explore", maxArgs: -1, callback: record},
+		"alias":   all["alias"],
+		"macro":   all["macro"],
+	}
+	userSettings = userConfig{Aliases: map[string]string{}, Macros: map[string][]string{}}
+
+	inputs := []string{
+		"alias k=catch",
+		"alias gb='catch with great-ball'",
+		"macro hunt = explore $1; k $2",
+		"c pikachu",
+		"K Eevee",
+		"gb",
+		"hunt route-1 pidgey",
+	}
+	for _, input := range inputs {
+		if err := runCommand(nil, &pokeapi.Config{}, input, 0); err != nil {
+			t.Fatalf("%q: unexpected error: %v", input, err)
+		}
+	}
+	if expected := []string{"pikachu", "eevee", "with", "great-ball", "route-1", "pidgey"}; !slices.Equal(ran, expected) {
+		t.Errorf("expected the commands to get %q, got %q", expected, ran)
+	}
+
+	for _, input := range []string{"alias catch=explore", "alias c=explore", "alias x=unknown", "macro k = catch", "cath pikachu"} {
+		if err := runCommand(nil, &pokeapi.Config{}, input, 0); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+
+	// A command wins over an alias or macro taking its name
+	ran = nil
+	userSettings.Aliases["catch"] = "explore route-1"
+	userSettings.Macros["c"] = []string{"explore route-2"}
+	for _, input := range []string{"catch pikachu", "c eevee"} {
+		if err := runCommand(nil, &pokeapi.Config{}, input, 0); err != nil {
+			t.Fatalf("%q: unexpected error: %v", input, err)
+		}
+	}
+	if expected := []string{"pikachu", "eevee"}; !slices.Equal(ran, expected) {
+		t.Errorf("expected the commands to get %q, got %q", expected, ran)
+	}
+	delete(userSettings.Aliases, "catch")
+	delete(userSettings.Macros, "c")
+
+	userSettings.Aliases["loop"] = "loop"
+	if err := runCommand(nil, &pokeapi.Config{}, "loop", 0); err == nil {
+		t.Error("expected an error for an alias expanding to itself")
+	}
+	delete(userSettings.Aliases, "loop")
+
+	path, err := userConfigFilePath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := loadUserConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Aliases["k"] != "catch" || !slices.Equal(loaded.Macros["hunt"], []string{"explore $1", "k $2"}) {
+		t.Errorf("aliases and macros not saved, got %+v", loaded)
+	}
+}
+
+func TestLoadUserConfigClashes(t *testing.T) {
+	original := commandLists
+	t.Cleanup(func() { commandLists = original })
+	commandLists = map[string]cliCommand{"catch": {name: "catch", aliases: []string{"c"}}}
+
+	path := filepath.Join(t.TempDir(), userConfigFileName)
+	body := `{"aliases": {"catch": "map", "k": "catch"}, "macros": {"c": ["map"], "hunt": ["catch $1"]}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := loadUserConfig(path)
+	if err == nil {
+		t.Error("expected an error for the names taken by commands")
+	}
+	if _, ok := settings.Aliases["catch"]; ok || settings.Aliases["k"] != "catch" {
+		t.Errorf("expected only the alias k to be kept, got %v", settings.Aliases)
+	}
+	if _, ok := settings.Macros["c"]; ok || len(settings.Macros["hunt"]) != 1 {
+		t.Errorf("expected only the macro hunt to be kept, got %v", settings.Macros)
+	}
+}
+
+func TestLoadUserConfigMissing(t *testing.T) {
+	settings, err := loadUserConfig(filepath.Join(t.TempDir(), userConfigFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Aliases == nil || settings.Macros == nil || len(settings.Aliases)+len(settings.Macros) != 0 {
+		t.Errorf("expected empty aliases and macros, got %+v", settings)
+	}
+}
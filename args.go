@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// splitArgs splits a command line into words like a shell does: words are
+// separated by spaces, single quotes keep everything in them as is, double
+// quotes keep spaces, and a backslash escapes the next character outside
+// single quotes. E.g. nickname 3 "Mr. Sparky" gives three words.
+func splitArgs(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord, escaped := false, false
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("nothing to escape at the end of the line")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
+
+// splitCommands splits line into the command lines separated by
+// semicolons, leaving semicolons quoted or escaped as splitArgs does.
+// Blank command lines are dropped.
+func splitCommands(line string) ([]string, error) {
+	var lines []string
+	start, escaped := 0, false
+	var quote rune
+
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			lines = append(lines, line[start:i])
+			start = i + 1
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	lines = append(lines, line[start:])
+
+	nonBlank := lines[:0]
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			nonBlank = append(nonBlank, l)
+		}
+	}
+	return nonBlank, nil
+}
+
+// usageLine returns how the command is typed, e.g. "nickname <id> [name]",
+// from its arguments and flags
+func (c cliCommand) usageLine() string {
+	words := []string{c.name}
+	for _, arg := range c.arguments {
+		words = append(words, arg.name)
+	}
+	for _, f := range c.flags {
+		words = append(words, "[--"+strings.TrimSpace(f.name+" "+f.value)+"]")
+	}
+	return strings.Join(words, " ")
+}
+
+// parseArgs separates the --flags of a command from its arguments and
+// checks them against what the command accepts. Flags are written --name,
+// --name=value, or --name value for flags taking a value; a lone --
+// makes the following words arguments. Arguments and flag values are
+// lowercased unless the command keeps their case.
+func parseArgs(command cliCommand, words []string) ([]string, map[string]string, error) {
+	args := []string{}
+	flags := make(map[string]string)
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			args = append(args, words[i+1:]...)
+			break
+		}
+
+		name, isFlag := strings.CutPrefix(word, "--")
+		if !isFlag {
+			args = append(args, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(name, "=")
+		name = strings.ToLower(name)
+		flag, known := command.flag(name)
+		takesValue := flag.value != ""
+		switch {
+		case !known:
+			return nil, nil, fmt.Errorf("unknown flag --%s. Usage: %s", name, command.usageLine())
+		case takesValue && !hasValue:
+			if i+1 == len(words) {
+				return nil, nil, fmt.Errorf("--%s needs a value. Usage: %s", name, command.usageLine())
+			}
+			i++
+			value = words[i]
+		case !takesValue && hasValue:
+			return nil, nil, fmt.Errorf("--%s doesn't take a value. Usage: %s", name, command.usageLine())
+		}
+		flags[name] = value
+	}
+
+	switch {
+	case len(args) < command.minArgs:
+		return nil, nil, fmt.Errorf("missing arguments. Usage: %s", command.usageLine())
+	case command.maxArgs >= 0 && len(args) > command.maxArgs:
+		return nil, nil, fmt.Errorf("too many arguments. Usage: %s", command.usageLine())
+	}
+
+	if !command.keepCase {
+		for i := range args {
+			args[i] = strings.ToLower(args[i])
+		}
+		for name, value := range flags {
+			flags[name] = strings.ToLower(value)
+		}
+	}
+
+	return args, flags, nil
+}
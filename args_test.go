@@ -0,0 +1,122 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	cases := map[string][]string{
+		"  catch   pikachu ":             {"catch", "pikachu"},
+		`nickname 3 "Mr. Sparky"`:        {"nickname", "3", "Mr. Sparky"},
+		`nickname 3 'Say "hi"'`:          {"nickname", "3", `Say "hi"`},
+		`nickname 3 Mr\ Sparky`:          {"nickname", "3", "Mr Sparky"},
+		`nickname 3 "a \"quoted\" name"`: {"nickname", "3", `a "quoted" name`},
+		`use ""`:                         {"use", ""},
+		`pokedex --region="kanto"`:       {"pokedex", "--region=kanto"},
+		"":                               nil,
+	}
+	for line, expected := range cases {
+		words, err := splitArgs(line)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", line, err)
+			continue
+		}
+		if !slices.Equal(words, expected) {
+			t.Errorf("%q: expected %q, got %q", line, expected, words)
+		}
+	}
+
+	for _, line := range []string{`nickname 3 "Sparky`, `nickname 3 'Sparky`, `catch pikachu\`} {
+		if _, err := splitArgs(line); err == nil {
+			t.Errorf("%q: expected an error", line)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	pokedex := cliCommand{name: "pokedex", maxArgs: 1, flags: []commandFlag{{name: "region", value: "<region>"}, {name: "all"}}}
+	nickname := cliCommand{name: "nickname", minArgs: 1, maxArgs: -1, keepCase: true}
+
+	cases := []struct {
+		command cliCommand
+		words   []string
+		args    []string
+		flags   map[string]string
+	}{
+		{pokedex, nil, []string{}, map[string]string{}},
+		{pokedex, []string{"Completion"}, []string{"completion"}, map[string]string{}},
+		{pokedex, []string{"--region", "Johto"}, []string{}, map[string]string{"region": "johto"}},
+		{pokedex, []string{"--region=kanto", "--all"}, []string{}, map[string]string{"region": "kanto", "all": ""}},
+		{pokedex, []string{"--", "--all"}, []string{"--all"}, map[string]string{}},
+		{nickname, []string{"3", "Mr.", "Sparky"}, []string{"3", "Mr.", "Sparky"}, map[string]string{}},
+		{nickname, []string{"3", "-5"}, []string{"3", "-5"}, map[string]string{}},
+	}
+	for _, c := range cases {
+		args, flags, err := parseArgs(c.command, c.words)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", c.command.name, c.words, err)
+			continue
+		}
+		if !slices.Equal(args, c.args) || !maps.Equal(flags, c.flags) {
+			t.Errorf("%s %q: expected %q %v, got %q %v", c.command.name, c.words, c.args, c.flags, args, flags)
+		}
+	}
+
+	failures := []struct {
+		command cliCommand
+		words   []string
+	}{
+		{pokedex, []string{"completion", "extra"}},
+		{pokedex, []string{"--color"}},
+		{pokedex, []string{"--region"}},
+		{pokedex, []string{"--all=yes"}},
+		{nickname, nil},
+	}
+	for _, c := range failures {
+		if _, _, err := parseArgs(c.command, c.words); err == nil {
+			t.Errorf("%s %q: expected an error", c.command.name, c.words)
+		}
+	}
+}
+
+func TestSplitCommands(t *testing.T) {
+	cases := map[string][]string{
+		"explore $1; catch $2":     {"explore $1", "catch $2"},
+		`nickname 3 "a;b"; party;`: {`nickname 3 "a;b"`, "party"},
+		`nickname 3 a\;b`:          {`nickname 3 a\;b`},
+		" ; ":                      {},
+	}
+	for line, expected := range cases {
+		lines, err := splitCommands(line)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", line, err)
+			continue
+		}
+		if !slices.Equal(lines, expected) {
+			t.Errorf("%q: expected %q, got %q", line, expected, lines)
+		}
+	}
+
+	if _, err := splitCommands(`party; nickname 3 "a`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestUsageLine(t *testing.T) {
+	cases := map[string]string{
+		"map":      "map [page]",
+		"nickname": "nickname <id> [name]",
+		"swap":     "swap <id> <id>",
+		"battle":   "battle [<pokemon_name|#number>] [level]",
+		"pokedex":  "pokedex [completion] [--region <region>] [--dex <name|number>]",
+		"exit":     "exit",
+	}
+	commands := commands()
+	for name, expected := range cases {
+		if usage := commands[name].usageLine(); usage != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, usage)
+		}
+	}
+}
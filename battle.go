@@ -0,0 +1,668 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// maxMoves is how many moves a Pokemon knows at once
+	maxMoves = 4
+	// defaultTrainerLevel is the level of a trainer's Pokemon when none is given
+	defaultTrainerLevel = 20
+	// critChance is the 1-in-n chance of landing a critical hit
+	critChance = 24
+)
+
+// battleCommands are the commands allowed while a battle is going on
+var battleCommands = map[string]bool{
+	"fight":   true,
+	"switch":  true,
+	"bag":     true,
+	"run":     true,
+	"help":    true,
+	"use":     true,
+	"inspect": true,
+	"matchup": true,
+	"pokedex": true,
+	"party":   true,
+	"money":   true,
+	"history": true,
+	"exit":    true,
+}
+
+// battleMove is a move known by a Pokemon in battle
+type battleMove struct {
+	name        string
+	moveType    string
+	damageClass string // physical, special or status
+	power       int
+	accuracy    int // 0 means the move never misses
+	priority    int
+	pp          int
+	maxPP       int
+	// ailment is the status condition the move may cause, with a chance
+	// of ailmentChance percent
+	ailment       string
+	ailmentChance int
+}
+
+// struggle is used when a Pokemon has no move left to use
+var struggle = battleMove{name: "struggle", moveType: "typeless", damageClass: "physical", power: 50}
+
+// battler is a Pokemon taking part in a battle
+type battler struct {
+	owned *pokeapi.OwnedPokemon
+	types []string
+	hp    int
+	maxHP int
+	moves []*battleMove
+	// status is the major status condition, "" when healthy
+	status string
+	// sleepTurns is how many more turns a sleeping Pokemon sleeps
+	sleepTurns int
+}
+
+func (b *battler) name() string {
+	return b.owned.Name()
+}
+
+func (b *battler) level() int {
+	return b.owned.Level
+}
+
+func (b *battler) stat(name string) int {
+	return b.owned.Stats[name]
+}
+
+// speed returns the speed of b, halved by paralysis
+func (b *battler) speed() int {
+	if b.status == statusParalysis {
+		return b.stat("speed") / 2
+	}
+	return b.stat("speed")
+}
+
+func (b *battler) fainted() bool {
+	return b.hp <= 0
+}
+
+func (b *battler) String() string {
+	s := fmt.Sprintf("%s Lv.%d HP %d/%d", b.name(), b.level(), b.hp, b.maxHP)
+	if label, ok := statusLabels[b.status]; ok {
+		s += " " + label
+	}
+	return s
+}
+
+// battle is the state of an ongoing battle
+type battle struct {
+	r           *rand.Rand
+	team        []*battler
+	active      int
+	foe         *battler
+	isWild      bool
+	runAttempts int
+	generation  int
+}
+
+// currentBattle is the ongoing battle, nil outside of battles
+var currentBattle *battle
+
+func (b *battle) player() *battler {
+	return b.team[b.active]
+}
+
+// learnableMoves returns the moves pokemon learns by level-up at or below
+// level, the most recently learned first
+func learnableMoves(pokemon *pokeapi.Pokemon, level int) []string {
+	learnedAt := make(map[string]int)
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt > level {
+				continue
+			}
+			if current, seen := learnedAt[move.Move.Name]; !seen || detail.LevelLearnedAt > current {
+				learnedAt[move.Move.Name] = detail.LevelLearnedAt
+			}
+		}
+	}
+
+	names := make([]string, 0, len(learnedAt))
+	for name := range learnedAt {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if learnedAt[names[i]] != learnedAt[names[j]] {
+			return learnedAt[names[i]] > learnedAt[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// loadBattleMoves fetches move data for names, keeping the first damaging
+// moves. Moves are fetched maxMoves at a time, so a long list of learnable
+// moves stops being fetched once enough damaging ones are found.
+func loadBattleMoves(client *pokeapi.Client, names []string) ([]*battleMove, error) {
+	var moves []*battleMove
+	for start := 0; start < len(names) && len(moves) < maxMoves; start += maxMoves {
+		chunk := names[start:min(start+maxMoves, len(names))]
+		for _, result := range client.GetMoveBatch(chunk, pokeapi.BatchOptions{}) {
+			if len(moves) == maxMoves {
+				break
+			}
+
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			if move := newBattleMove(result.Value); move != nil {
+				moves = append(moves, move)
+			}
+		}
+	}
+
+	return moves, nil
+}
+
+// newBattleMove returns move as used in battle, nil for moves dealing no damage
+func newBattleMove(move *pokeapi.Move) *battleMove {
+	if move.Power == nil || *move.Power == 0 {
+		return nil
+	}
+
+	m := &battleMove{
+		name:        move.Name,
+		moveType:    move.Type.Name,
+		damageClass: move.DamageClass.Name,
+		power:       *move.Power,
+		priority:    move.Priority,
+		pp:          move.PP,
+		maxPP:       move.PP,
+	}
+	if move.Accuracy != nil {
+		m.accuracy = *move.Accuracy
+	}
+	if move.Meta != nil {
+		m.ailment, m.ailmentChance = move.Meta.Ailment.Name, move.Meta.AilmentChance
+	}
+
+	return m
+}
+
+// newBattler prepares owned for battle, with its types in gen
+func newBattler(client *pokeapi.Client, owned *pokeapi.OwnedPokemon, gen int) (*battler, error) {
+	moves, err := loadBattleMoves(client, owned.Moves)
+	if err != nil {
+		return nil, err
+	}
+
+	return &battler{
+		owned: owned,
+		types: typesForGeneration(owned.Pokemon, gen),
+		hp:    currentHP(owned),
+		maxHP: owned.Stats["hp"],
+		moves: moves,
+	}, nil
+}
+
+// calcDamage applies the main-series damage formula: base damage from level,
+// power and attack/defense, then STAB, type effectiveness, critical hit and
+// a random factor between 0.85 and 1
+func calcDamage(r *rand.Rand, attacker, defender *battler, move *battleMove, effectiveness float64) (damage int, crit bool) {
+	attack, defense := attacker.stat("attack"), defender.stat("defense")
+	if move.damageClass == "special" {
+		attack, defense = attacker.stat("special-attack"), defender.stat("special-defense")
+	}
+	defense = max(defense, 1)
+
+	base := (2*attacker.level()/5+2)*move.power*attack/defense/50 + 2
+
+	modifier := effectiveness
+	for _, t := range attacker.types {
+		if t == move.moveType {
+			modifier *= 1.5
+			break
+		}
+	}
+	// A burn halves the damage of physical moves
+	if attacker.status == statusBurn && move.damageClass == "physical" {
+		modifier *= 0.5
+	}
+	if r.Intn(critChance) == 0 {
+		crit = true
+		modifier *= 1.5
+	}
+	modifier *= float64(85+r.Intn(16)) / 100
+
+	damage = int(float64(base) * modifier)
+	if damage < 1 && effectiveness > 0 {
+		damage = 1
+	}
+
+	return damage, crit
+}
+
+// useMove makes attacker use move on defender and prints what happened
+func (b *battle) useMove(client *pokeapi.Client, attacker, defender *battler, move *battleMove) error {
+	if !b.canMove(attacker) {
+		return nil
+	}
+	if move != &struggle {
+		move.pp--
+	}
+	fmt.Printf("%s used %s!\n", attacker.name(), move.name)
+
+	if move.accuracy > 0 && b.r.Intn(100) >= move.accuracy {
+		fmt.Printf("%s's attack missed!\n", attacker.name())
+		return nil
+	}
+
+	effectiveness, err := typeEffectiveness(client, b.generation, move.moveType, defender.types)
+	if err != nil {
+		return err
+	}
+	if effectiveness == 0 {
+		fmt.Printf("It doesn't affect %s...\n", defender.name())
+		return nil
+	}
+
+	damage, crit := calcDamage(b.r, attacker, defender, move, effectiveness)
+	defender.hp = max(defender.hp-damage, 0)
+
+	if crit {
+		fmt.Println("A critical hit!")
+	}
+	switch {
+	case effectiveness > 1:
+		fmt.Println("It's super effective!")
+	case effectiveness < 1:
+		fmt.Println("It's not very effective...")
+	}
+	fmt.Printf("%s\n", defender)
+
+	if !defender.fainted() {
+		b.inflictStatus(defender, move)
+	}
+
+	return nil
+}
+
+// pickMove picks a random move with PP left, or struggle when there is none
+func (b *battle) pickMove(attacker *battler) *battleMove {
+	var usable []*battleMove
+	for _, m := range attacker.moves {
+		if m.pp > 0 {
+			usable = append(usable, m)
+		}
+	}
+	if len(usable) == 0 {
+		return &struggle
+	}
+	return usable[b.r.Intn(len(usable))]
+}
+
+// playerMovesFirst decides the turn order from move priority, then speed
+func (b *battle) playerMovesFirst(playerMove, foeMove *battleMove) bool {
+	if playerMove.priority != foeMove.priority {
+		return playerMove.priority > foeMove.priority
+	}
+
+	playerSpeed, foeSpeed := b.player().speed(), b.foe.speed()
+	if playerSpeed != foeSpeed {
+		return playerSpeed > foeSpeed
+	}
+
+	return b.r.Intn(2) == 0
+}
+
+// fightTurn plays a turn where the player attacks with playerMove
+func (b *battle) fightTurn(client *pokeapi.Client, config *pokeapi.Config, playerMove *battleMove) error {
+	foeMove := b.pickMove(b.foe)
+
+	first, firstMove, second, secondMove := b.player(), playerMove, b.foe, foeMove
+	if !b.playerMovesFirst(playerMove, foeMove) {
+		first, firstMove, second, secondMove = b.foe, foeMove, b.player(), playerMove
+	}
+
+	if err := b.useMove(client, first, second, firstMove); err != nil {
+		return err
+	}
+	if !second.fainted() {
+		if err := b.useMove(client, second, first, secondMove); err != nil {
+			return err
+		}
+	}
+
+	return b.afterTurn(client, config)
+}
+
+// foeTurn lets the foe attack after the player spent the turn on something else
+func (b *battle) foeTurn(client *pokeapi.Client, config *pokeapi.Config) error {
+	if err := b.useMove(client, b.foe, b.player(), b.pickMove(b.foe)); err != nil {
+		return err
+	}
+	return b.afterTurn(client, config)
+}
+
+// afterTurn applies burn and poison damage, handles fainted Pokemon and
+// ends the battle when one side is out
+func (b *battle) afterTurn(client *pokeapi.Client, config *pokeapi.Config) error {
+	for _, active := range []*battler{b.player(), b.foe} {
+		if !active.fainted() {
+			statusDamage(active)
+		}
+	}
+
+	if b.foe.fainted() {
+		fmt.Printf("%s fainted!\n", b.foe.name())
+		reward := battleReward(b.foe.level())
+		config.Money += reward
+		fmt.Printf("You won the battle and earned ₽%d!\n", reward)
+		b.end()
+
+		// The Pokemon that won gains experience and the effort values of the defeated one
+		winner, defeated := b.player().owned, b.foe.owned
+		awardEVs(winner, defeated.Pokemon.Stats)
+		exp := experienceYield(defeated.Pokemon.BaseExperience, defeated.Level, !b.isWild)
+		if err := gainExperience(client, config, winner, exp); err != nil {
+			return err
+		}
+		return refreshStats(client, winner)
+	}
+
+	if !b.player().fainted() {
+		return nil
+	}
+
+	fmt.Printf("%s fainted!\n", b.player().name())
+	for i, member := range b.team {
+		if !member.fainted() {
+			b.active = i
+			fmt.Printf("Go! %s!\n", member)
+			return nil
+		}
+	}
+
+	fmt.Println("You have no more Pokemon that can fight. You blacked out!")
+	b.end()
+	for _, member := range b.team {
+		member.owned.Damage = 0
+	}
+	fmt.Println("You hurried back to a Pokemon Center, where your Pokemon were healed.")
+
+	return nil
+}
+
+// end finishes the battle. The Pokemon keep the HP they lost until healed.
+func (b *battle) end() {
+	for _, member := range b.team {
+		member.owned.Damage = member.maxHP - member.hp
+	}
+	b.foe.owned.Damage = b.foe.maxHP - b.foe.hp
+	if b.isWild {
+		wild = nil
+	}
+	currentBattle = nil
+}
+
+// battleReward returns the PokeDollars earned for defeating a Pokemon of level
+func battleReward(level int) int {
+	return 10 * level
+}
+
+// commandBattle starts a battle against the wild Pokemon in front of the
+// player, or against a trainer's Pokemon. Usage: battle [<pokemon_name|#number>] [level]
+func commandBattle(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if currentBattle != nil {
+		return fmt.Errorf("you are already in a battle")
+	}
+
+	team := partyPokemon(config)
+	if len(team) == 0 {
+		return fmt.Errorf("you have no Pokemon to battle with. Catch one first")
+	}
+
+	foeName, level, isWild := "", defaultTrainerLevel, len(args) == 0
+	if isWild {
+		if wild == nil {
+			return fmt.Errorf("there is no wild Pokemon around. Look for one with walk, fish or surf, or battle a trainer with: battle <pokemon_name> [level]")
+		}
+		foeName, level = wild.name, wild.level
+	} else {
+		foeName = args[0]
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 || n > 100 {
+				return fmt.Errorf("level must be a number between 1 and 100. Usage: %s", commandLists["battle"].usageLine())
+			}
+			level = n
+		}
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := activeGeneration(config)
+
+	foePokemon, err := fetchPokemon(client, foeName)
+	if err != nil {
+		return err
+	}
+	registerSeenPokemon(config, foePokemon.Summary())
+	foeOwned, err := newOwnedPokemon(client, r, foePokemon, level)
+	if err != nil {
+		return err
+	}
+	foe, err := newBattler(client, foeOwned, gen)
+	if err != nil {
+		return err
+	}
+
+	b := &battle{
+		r:          r,
+		foe:        foe,
+		isWild:     isWild,
+		generation: gen,
+		active:     -1,
+	}
+
+	for i, owned := range team {
+		member, err := newBattler(client, owned, gen)
+		if err != nil {
+			return err
+		}
+		b.team = append(b.team, member)
+		if b.active < 0 && !member.fainted() {
+			b.active = i
+		}
+	}
+	if b.active < 0 {
+		return fmt.Errorf("all your Pokemon have fainted. Heal them with: use potion <id>")
+	}
+
+	currentBattle = b
+	if isWild {
+		fmt.Printf("Wild %s appeared!\n", foe)
+	} else {
+		fmt.Printf("A trainer sent out %s!\n", foe)
+	}
+	fmt.Printf("Go! %s!\n", b.player())
+	printBattleMenu(b.player())
+
+	return nil
+}
+
+// printBattleMenu lists the moves of the active Pokemon and the available actions
+func printBattleMenu(active *battler) {
+	fmt.Println("Moves:")
+	for _, m := range active.moves {
+		fmt.Printf(" - %s (%s, power %d, PP %d/%d)\n", m.name, m.moveType, m.power, m.pp, m.maxPP)
+	}
+	fmt.Println("Use: fight <move>, switch <pokemon>, use <item> [id] or run")
+}
+
+// commandFight attacks with one of the active Pokemon's moves. Usage: fight [move]
+func commandFight(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	b := currentBattle
+	if b == nil {
+		return fmt.Errorf("you are not in a battle")
+	}
+
+	moveName := normalizeItemName(strings.Join(args, " "))
+	if moveName == "" {
+		printBattleMenu(b.player())
+		return nil
+	}
+
+	var move *battleMove
+	for _, m := range b.player().moves {
+		if m.name == moveName {
+			move = m
+		}
+	}
+
+	usable := false
+	for _, m := range b.player().moves {
+		usable = usable || m.pp > 0
+	}
+	switch {
+	case !usable:
+		// Out of PP on every move: the Pokemon struggles
+		move = &struggle
+	case move == nil:
+		err := fmt.Errorf("%s doesn't know %s", b.player().name(), moveName)
+		return suggestAmong(client, "move", moveName, battleMoveNames(config), err)
+	case move.pp == 0:
+		return fmt.Errorf("there is no PP left for %s", move.name)
+	}
+
+	return b.fightTurn(client, config, move)
+}
+
+// commandSwitch sends out another Pokemon from the team. Usage: switch [pokemon_name]
+func commandSwitch(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	b := currentBattle
+	if b == nil {
+		return fmt.Errorf("you are not in a battle")
+	}
+
+	if len(args) == 0 {
+		fmt.Println("Your team:")
+		for i, member := range b.team {
+			marker := " "
+			if i == b.active {
+				marker = "*"
+			}
+			fmt.Printf(" %s %s\n", marker, member)
+		}
+		return nil
+	}
+
+	name := strings.Join(args, " ")
+	for i, member := range b.team {
+		if !strings.EqualFold(member.name(), name) {
+			continue
+		}
+		if i == b.active {
+			return fmt.Errorf("%s is already in battle", member.name())
+		}
+		if member.fainted() {
+			return fmt.Errorf("%s has fainted and can't battle", member.name())
+		}
+
+		fmt.Printf("%s, come back!\n", b.player().name())
+		b.active = i
+		fmt.Printf("Go! %s!\n", member)
+
+		return b.foeTurn(client, config)
+	}
+
+	return fmt.Errorf("%s is not in your team", name)
+}
+
+// commandRun tries to flee from a wild battle
+func commandRun(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	b := currentBattle
+	if b == nil {
+		return fmt.Errorf("you are not in a battle")
+	}
+
+	if !b.isWild {
+		return fmt.Errorf("no! There's no running from a trainer battle")
+	}
+
+	// Escape odds from the main series: faster Pokemon always get away,
+	// slower ones get better odds with every attempt
+	b.runAttempts++
+	playerSpeed, foeSpeed := b.player().speed(), max(b.foe.speed(), 1)
+	odds := (playerSpeed*128/foeSpeed + 30*b.runAttempts) % 256
+	if playerSpeed >= foeSpeed || b.r.Intn(256) < odds {
+		fmt.Println("Got away safely!")
+		b.end()
+		return nil
+	}
+
+	fmt.Println("Can't escape!")
+
+	return b.foeTurn(client, config)
+}
+
+// battleUseItem uses an item from the bag during a battle, spending the turn
+func battleUseItem(client *pokeapi.Client, config *pokeapi.Config, itemName string) error {
+	b := currentBattle
+
+	if config.Inventory[itemName] == 0 {
+		return missingItemError(client, config, itemName)
+	}
+	effect, ok := supportedItems[itemName]
+	if !ok || effect.kind == itemEvolution {
+		return fmt.Errorf("%s can't be used in battle", itemName)
+	}
+
+	if effect.kind == itemBall {
+		if !b.isWild {
+			return fmt.Errorf("you can't catch a trainer's Pokemon")
+		}
+
+		fmt.Printf("Throwing a %s at %s...\n", itemName, b.foe.name())
+		caught, err := throwBall(client, config, b.r, b.foe.owned.Pokemon, itemName, b.foe.hp, b.foe.maxHP, b.foe.status)
+		if err != nil {
+			return err
+		}
+		if caught {
+			registerCatch(config, b.foe.owned)
+			b.end()
+
+			exp := experienceYield(b.foe.owned.Pokemon.BaseExperience, b.foe.level(), false)
+			return gainExperience(client, config, b.player().owned, exp)
+		}
+
+		fmt.Printf("%s escaped!\n", b.foe.name())
+		return b.foeTurn(client, config)
+	}
+
+	return b.heal(client, config, b.player(), itemName)
+}
+
+// heal restores the HP of target, a Pokemon of the team, with a medicine
+// or berry from the bag, spending the turn
+func (b *battle) heal(client *pokeapi.Client, config *pokeapi.Config, target *battler, itemName string) error {
+	if target.hp == target.maxHP {
+		return fmt.Errorf("%s is already at full health", target.name())
+	}
+	if err := removeItem(config, itemName, 1); err != nil {
+		return err
+	}
+
+	healed := healAmount(supportedItems[itemName], target.hp, target.maxHP)
+	target.hp += healed
+	fmt.Printf("%s recovered %d HP. %s\n", target.name(), healed, target)
+
+	return b.foeTurn(client, config)
+}
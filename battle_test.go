@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestLearnableMoves(t *testing.T) {
+	var pokemon pokeapi.Pokemon
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "thunder-shock"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "quick-attack"}, "version_group_details": [{"level_learned_at": 8, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "thunderbolt"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "machine"}}]},
+		{"move": {"name": "thunder"}, "version_group_details": [{"level_learned_at": 40, "move_learn_method": {"name": "level-up"}}]}
+	]}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moves := learnableMoves(&pokemon, 10)
+	expected := []string{"quick-attack", "thunder-shock"}
+	if len(moves) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, moves)
+	}
+	for i := range expected {
+		if moves[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, moves)
+		}
+	}
+}
+
+func TestCalcDamage(t *testing.T) {
+	attacker := &battler{
+		owned: &pokeapi.OwnedPokemon{Level: 50, Stats: map[string]int{"special-attack": 70}},
+		types: []string{"electric"},
+	}
+	defender := &battler{owned: &pokeapi.OwnedPokemon{Level: 50, Stats: map[string]int{"special-defense": 70}}}
+	move := &battleMove{name: "thunderbolt", moveType: "electric", damageClass: "special", power: 90}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		// Base damage is 41: 22*90*70/70/50 + 2, then 1.5 STAB and 2x effectiveness
+		damage, crit := calcDamage(r, attacker, defender, move, 2)
+		low, high := 104, 123
+		if crit {
+			low, high = 156, 184
+		}
+		if damage < low || damage > high {
+			t.Errorf("damage %d out of range %d-%d (crit=%v)", damage, low, high, crit)
+		}
+	}
+}
+
+func TestPlayerMovesFirst(t *testing.T) {
+	b := &battle{
+		r:    rand.New(rand.NewSource(1)),
+		team: []*battler{{owned: &pokeapi.OwnedPokemon{Stats: map[string]int{"speed": 50}}}},
+		foe:  &battler{owned: &pokeapi.OwnedPokemon{Stats: map[string]int{"speed": 100}}},
+	}
+
+	if b.playerMovesFirst(&battleMove{}, &battleMove{}) {
+		t.Errorf("expected the faster foe to move first")
+	}
+	if !b.playerMovesFirst(&battleMove{priority: 1}, &battleMove{}) {
+		t.Errorf("expected the priority move to go first")
+	}
+}
+
+func TestLoadBattleMovesStopsEarly(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		name := strings.TrimPrefix(r.URL.Path, "/move/")
+		fmt.Fprintf(w, `{"name": %q, "power": 40, "pp": 35, "type": {"name": "normal"}, "damage_class": {"name": "physical"}}`, name)
+	}))
+	t.Cleanup(server.Close)
+	client := pokeapi.NewClient()
+	client.BaseURL = server.URL
+
+	names := make([]string, 30)
+	for i := range names {
+		names[i] = fmt.Sprintf("move-%d", i)
+	}
+	moves, err := loadBattleMoves(client, names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moves) != maxMoves || moves[0].name != "move-0" {
+		t.Errorf("expected the first %d moves, got %d", maxMoves, len(moves))
+	}
+	if n := requests.Load(); n != maxMoves {
+		t.Errorf("expected %d moves fetched, got %d", maxMoves, n)
+	}
+}
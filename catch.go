@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	catchModeClassic   = "classic"
+	catchModeAuthentic = "authentic"
+)
+
+// shakeDelay is the pause between two shake checks so they are printed one by one
+var shakeDelay = 600 * time.Millisecond
+
+// statusCatchBonus holds the status multipliers used by the main-series capture formula
+var statusCatchBonus = map[string]float64{
+	statusSleep:     2,
+	statusFreeze:    2,
+	statusParalysis: 1.5,
+	statusPoison:    1.5,
+	statusBurn:      1.5,
+}
+
+// catchAttempt gathers everything the authentic capture formula depends on
+type catchAttempt struct {
+	captureRate int     // capture_rate of the species (3 for legendaries, 255 for Caterpie...)
+	maxHP       int     // maximum HP of the wild Pokemon
+	currentHP   int     // remaining HP of the wild Pokemon
+	status      string  // status condition, "" when healthy
+	ballBonus   float64 // ball multiplier (1 for a Poke Ball)
+}
+
+// modifiedCatchRate computes the "a" value of the Gen III/IV capture formula:
+// a = ((3*maxHP - 2*currentHP) * rate * ball) / (3*maxHP) * status
+func (a catchAttempt) modifiedCatchRate() float64 {
+	maxHP := a.maxHP
+	if maxHP <= 0 {
+		maxHP = 1
+	}
+	currentHP := min(max(a.currentHP, 1), maxHP)
+
+	ballBonus := a.ballBonus
+	if ballBonus <= 0 {
+		ballBonus = 1
+	}
+
+	statusBonus := 1.0
+	if bonus, ok := statusCatchBonus[a.status]; ok {
+		statusBonus = bonus
+	}
+
+	rate := float64(3*maxHP-2*currentHP) * float64(a.captureRate) * ballBonus / float64(3*maxHP)
+
+	return math.Floor(rate) * statusBonus
+}
+
+// shakeThreshold computes the "b" value: each shake check passes when a
+// random number in [0, 65535] is lower than it
+func shakeThreshold(modifiedRate float64) int {
+	if modifiedRate <= 0 {
+		return 0
+	}
+	if modifiedRate >= 255 {
+		return 65536
+	}
+
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/modifiedRate)))
+}
+
+// rollShakeChecks performs up to four shake checks and reports how many passed.
+// The Pokemon is caught only when all four checks pass.
+func rollShakeChecks(r *rand.Rand, attempt catchAttempt) (passed int, caught bool) {
+	threshold := shakeThreshold(attempt.modifiedCatchRate())
+
+	for passed < 4 {
+		if r.Intn(65536) >= threshold {
+			return passed, false
+		}
+		passed++
+	}
+
+	return passed, true
+}
+
+// catchAuthentic runs the main-series capture formula, printing the shakes one by one
+func catchAuthentic(r *rand.Rand, attempt catchAttempt) bool {
+	passed, caught := rollShakeChecks(r, attempt)
+
+	// The ball only visibly shakes three times, the fourth check is the "click"
+	for i := 0; i < min(passed, 3); i++ {
+		time.Sleep(shakeDelay)
+		fmt.Println("...shake...")
+	}
+	time.Sleep(shakeDelay)
+
+	return caught
+}
+
+// catchClassic uses the original base-experience formula, scaled by the ball bonus
+func catchClassic(r *rand.Rand, baseExperience int, ballBonus float64) bool {
+	pokemonBaseCatchProbability := min(calculateCatchProbability(baseExperience)*ballBonus, 1)
+
+	// Using rand.Intn for integer-based random value
+	// We'll use a scale of 100 to represent percentages
+	randomValue := r.Intn(100)
+	pokemonScaledProbability := pokemonBaseCatchProbability * 100
+
+	// If random value is less than scaled catch probability, the Pokémon is caught
+	return float64(randomValue) < pokemonScaledProbability
+}
+
+// throwBall uses up a ball and rolls the catch with the selected catch
+// mode. The HP and status condition of the Pokemon only count in the
+// authentic mode.
+func throwBall(client *pokeapi.Client, config *pokeapi.Config, r *rand.Rand, pokemon pokeapi.PokemonSummary, ball string, currentHP, maxHP int, status string) (bool, error) {
+	// The capture rate is fetched first, so a failed lookup doesn't waste the ball
+	var species *pokeapi.PokemonSpecies
+	if currentCatchMode(config) == catchModeAuthentic {
+		var err error
+		if species, err = client.GetPokemonSpecies(pokemon.Species.Name); err != nil {
+			return false, err
+		}
+	}
+
+	// The ball is used up whether the catch succeeds or not
+	if err := removeItem(config, ball, 1); err != nil {
+		return false, err
+	}
+	ballBonus := supportedItems[ball].ballBonus
+
+	if species == nil {
+		return catchClassic(r, pokemon.BaseExperience, ballBonus), nil
+	}
+
+	return catchAuthentic(r, catchAttempt{
+		captureRate: species.CaptureRate,
+		maxHP:       maxHP,
+		currentHP:   currentHP,
+		status:      status,
+		ballBonus:   ballBonus,
+	}), nil
+}
+
+// registerCatch adds owned to the caught Pokemon and pays the catch reward
+func registerCatch(config *pokeapi.Config, owned *pokeapi.OwnedPokemon) {
+	addOwnedPokemon(config, owned)
+	registerCaught(config, owned.Pokemon)
+
+	reward := catchReward(owned.Pokemon.BaseExperience)
+	config.Money += reward
+
+	// The wild Pokemon in front of the player is gone once caught
+	if wild != nil && wild.name == owned.Pokemon.Name {
+		wild = nil
+	}
+
+	fmt.Printf("%s (Lv. %d) was caught! It was registered with ID %d.\n", owned.Pokemon.Name, owned.Level, owned.ID)
+	if box, _, _ := findSlot(config, owned.ID); box > 0 {
+		fmt.Printf("Your party is full, so it was sent to box %d.\n", box)
+	}
+	fmt.Printf("You earned ₽%d.\n", reward)
+	fmt.Println("You may now inspect it with the inspect command.")
+}
+
+// commandCatchMode shows or switches the catch formula. Usage: catchmode [classic|authentic]
+func commandCatchMode(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		fmt.Printf("Catch mode: %s\n", currentCatchMode(config))
+		return nil
+	}
+
+	mode := args[0]
+	if mode != catchModeClassic && mode != catchModeAuthentic {
+		return fmt.Errorf("unknown catch mode %q. Usage: %s", mode, commandLists["catchmode"].usageLine())
+	}
+
+	config.CatchMode = mode
+	fmt.Printf("Catch mode set to %s\n", mode)
+
+	return nil
+}
+
+func currentCatchMode(config *pokeapi.Config) string {
+	if config.CatchMode == "" {
+		return catchModeClassic
+	}
+	return config.CatchMode
+}
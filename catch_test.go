@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestModifiedCatchRate(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempt  catchAttempt
+		expected float64
+	}{
+		{
+			name:     "full hp poke ball",
+			attempt:  catchAttempt{captureRate: 45, maxHP: 100, currentHP: 100, ballBonus: 1},
+			expected: 15,
+		},
+		{
+			name:     "1 hp ultra ball",
+			attempt:  catchAttempt{captureRate: 45, maxHP: 100, currentHP: 1, ballBonus: 2},
+			expected: 89,
+		},
+		{
+			name:     "asleep",
+			attempt:  catchAttempt{captureRate: 3, maxHP: 90, currentHP: 90, ballBonus: 1, status: "sleep"},
+			expected: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.attempt.modifiedCatchRate(); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRollShakeChecks(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// A modified rate of 255 or more is a guaranteed catch
+	passed, caught := rollShakeChecks(r, catchAttempt{captureRate: 255, maxHP: 1, currentHP: 1, ballBonus: 255})
+	if !caught || passed != 4 {
+		t.Errorf("expected guaranteed catch, got passed=%d caught=%v", passed, caught)
+	}
+
+	// A capture rate of 0 can never succeed
+	passed, caught = rollShakeChecks(r, catchAttempt{captureRate: 0, maxHP: 1, currentHP: 1, ballBonus: 1})
+	if caught || passed != 0 {
+		t.Errorf("expected no shakes, got passed=%d caught=%v", passed, caught)
+	}
+}
+
+func TestThrowBallKeepsBallOnLookupError(t *testing.T) {
+	client := newTestClient(t, map[string]string{})
+	config := &pokeapi.Config{CatchMode: catchModeAuthentic}
+	addItem(config, "poke-ball", 1)
+
+	pokemon := &pokeapi.Pokemon{Name: "pikachu"}
+	pokemon.Species.Name = "pikachu"
+	if _, err := throwBall(client, config, rand.New(rand.NewSource(1)), pokemon.Summary(), "poke-ball", 1, 1, ""); err == nil {
+		t.Fatal("expected an error when the species can't be fetched")
+	}
+	if config.Inventory["poke-ball"] != 1 {
+		t.Errorf("expected the ball to be kept, got %d", config.Inventory["poke-ball"])
+	}
+}
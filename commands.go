@@ -0,0 +1,656 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// Command categories, in the order help lists them
+const (
+	categoryNavigation = "Navigation"
+	categoryCollection = "Collection"
+	categoryBattle     = "Battle"
+	categorySystem     = "System"
+)
+
+var categories = []string{categoryNavigation, categoryCollection, categoryBattle, categorySystem}
+
+// commandArg documents an argument of a command
+type commandArg struct {
+	name        string // as written in the usage, e.g. "<id>"
+	description string
+}
+
+// commandFlag is a --flag accepted by a command
+type commandFlag struct {
+	name string
+	// value names the value of the flag, e.g. "<region>", empty for flags
+	// that are only set
+	value       string
+	description string
+}
+
+type cliCommand struct {
+	name        string
+	description string
+	category    string
+	// aliases are the built-in short names of the command
+	aliases []string
+	// arguments and flags make up the usage line, e.g. "nickname <id> [name]"
+	arguments []commandArg
+	flags     []commandFlag
+	examples  []string
+	// minArgs and maxArgs bound the number of arguments, maxArgs < 0 for any number
+	minArgs int
+	maxArgs int
+	// keepCase keeps the case of the arguments, lowercased otherwise
+	keepCase bool
+	// rawArgs passes everything after the command name as typed, as a
+	// single argument, for commands parsing it themselves
+	rawArgs bool
+	// complete returns what the next argument can complete to, nil for no completion
+	complete completer
+	callback func(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error
+}
+
+// helpWidth is the width help wraps descriptions to
+const helpWidth = 80
+
+// flag returns the flag of the command called name
+func (c cliCommand) flag(name string) (commandFlag, bool) {
+	for _, f := range c.flags {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return commandFlag{}, false
+}
+
+// argPokemon, argID and argItem are the arguments shared by many commands
+var (
+	argPokemon = commandArg{"<pokemon_name|#number>", "a Pokemon by name, or by national dex number written #25"}
+	argID      = commandArg{"<id>", "the ID of one of your Pokemon, shown by party, box and inspect"}
+	argItem    = commandArg{"<item>", "an item, e.g. great-ball or \"great ball\""}
+)
+
+// commands returns every command of the REPL by name
+func commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"exit": {
+			name:        "exit",
+			description: "Exit the Pokedex",
+			category:    categorySystem,
+			callback:    commandExit,
+		},
+		"help": {
+			name:        "help",
+			description: "Lists the commands, or shows how to use one",
+			category:    categorySystem,
+			arguments:   []commandArg{{"[command]", "the command to show the usage, arguments, flags and examples of"}},
+			examples:    []string{"help", "help catch"},
+			maxArgs:     1,
+			complete:    firstArg(commandNames),
+			callback:    commandHelp,
+		},
+		"map": {
+			name:        "map",
+			aliases:     []string{"m"},
+			description: "Displays the next 20 location areas, or the page given",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[page]", "the page to show, the next one by default"}},
+			examples:    []string{"map", "map 3"},
+			maxArgs:     1,
+			callback:    commandMap,
+		},
+		"mapb": {
+			name:        "mapb",
+			description: "Displays the previous 20 location areas",
+			category:    categoryNavigation,
+			callback:    commandMapb,
+		},
+		"explore": {
+			name:        "explore",
+			aliases:     []string{"e"},
+			description: "Explore a location area for Pokémon, by name or by its number in the last map or areas listing",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"<number|area_name>", "an area, or its number in the last map or areas listing"}},
+			examples:    []string{"explore 3", "explore viridian-forest-area"},
+			minArgs:     1,
+			maxArgs:     1,
+			complete:    firstArg(listedAreaNames),
+			callback:    commandExplore,
+		},
+		"locations": {
+			name:        "locations",
+			description: "Lists the locations of a region by number",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"<region>", "a region, see them with: regions"}},
+			examples:    []string{"locations kanto"},
+			minArgs:     1,
+			maxArgs:     1,
+			callback:    commandLocations,
+		},
+		"areas": {
+			name:        "areas",
+			description: "Lists the areas of a location by number",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"<number|location>", "a location, or its number in the last locations listing"}},
+			examples:    []string{"areas 12", "areas viridian-forest"},
+			minArgs:     1,
+			maxArgs:     1,
+			complete:    firstArg(listedLocationNames),
+			callback:    commandAreas,
+		},
+		"goto": {
+			name:        "goto",
+			description: "Travels to a location area, by name or by its number in the last map or areas listing, or shows where you are",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[number|area_name]", "an area, or its number in the last map or areas listing"}},
+			examples:    []string{"goto", "goto 3", "goto viridian-forest-area"},
+			maxArgs:     1,
+			complete:    firstArg(listedAreaNames),
+			callback:    commandGoto,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Same as goto",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[number|area_name]", "an area, or its number in the last map or areas listing"}},
+			examples:    []string{"travel viridian-forest-area"},
+			maxArgs:     1,
+			complete:    firstArg(listedAreaNames),
+			callback:    commandGoto,
+		},
+		"walk": {
+			name:        "walk",
+			description: "Walks through the tall grass of the current area looking for wild Pokemon",
+			category:    categoryNavigation,
+			callback:    commandWalk,
+		},
+		"fish": {
+			name:        "fish",
+			description: "Fishes in the current area",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[old-rod|good-rod|super-rod]", "the rod to fish with, the old rod by default"}},
+			examples:    []string{"fish", "fish super-rod"},
+			maxArgs:     1,
+			complete:    firstArg(rodNames),
+			callback:    commandFish,
+		},
+		"surf": {
+			name:        "surf",
+			description: "Surfs on the water of the current area looking for wild Pokemon",
+			category:    categoryNavigation,
+			callback:    commandSurf,
+		},
+		"version": {
+			name:        "version",
+			description: "Shows or sets the game version used for wild encounters",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[name|latest]", "a game version, or latest for the latest one available in each area"}},
+			examples:    []string{"version", "version red", "version latest"},
+			maxArgs:     1,
+			callback:    commandVersion,
+		},
+		"catch": {
+			name:        "catch",
+			aliases:     []string{"c"},
+			description: "Catching a Pokemon living in the current area, throwing a Poke Ball unless another ball is given",
+			category:    categoryCollection,
+			arguments:   []commandArg{argPokemon, {"[with <ball>]", "the ball to throw, a poke-ball by default"}},
+			examples:    []string{"catch pikachu", "catch #25 with great-ball"},
+			minArgs:     1,
+			maxArgs:     -1,
+			complete:    completeCatch,
+			callback:    commandCatch,
+		},
+		"catchmode": {
+			name:        "catchmode",
+			description: "Shows or switches the catch formula: classic (base experience) or authentic (main-series capture rate)",
+			category:    categorySystem,
+			arguments:   []commandArg{{"[classic|authentic]", "the catch formula to use"}},
+			examples:    []string{"catchmode", "catchmode authentic"},
+			maxArgs:     1,
+			complete:    firstArg(catchModeNames),
+			callback:    commandCatchMode,
+		},
+		"inspect": {
+			name:        "inspect",
+			aliases:     []string{"i"},
+			description: "It takes the ID or name of a caught Pokemon and prints its level, nature, base and actual stats, type(s) and abilities in the selected generation",
+			category:    categoryCollection,
+			arguments:   []commandArg{{"<id|#number|pokemon_name|nickname>", "one of your Pokemon, or all of them of a species"}},
+			examples:    []string{"inspect 3", "inspect #25", "inspect pikachu", "inspect \"Mr. Sparky\""},
+			minArgs:     1,
+			maxArgs:     -1,
+			callback:    commandInspect,
+		},
+		"bag": {
+			name:        "bag",
+			description: "Lists the items in your bag. In battle, uses an item",
+			category:    categoryCollection,
+			arguments:   []commandArg{{"[item]", "in battle, the item to use"}},
+			examples:    []string{"bag", "bag potion"},
+			maxArgs:     -1,
+			complete:    firstArg(inventoryItemNames),
+			callback:    commandBag,
+		},
+		"use": {
+			name:        "use",
+			description: "Uses an item from your bag on one of your Pokemon: medicine and berries heal it, evolution items evolve it. In battle, uses the item on the active Pokemon by default",
+			category:    categoryCollection,
+			arguments: []commandArg{
+				argItem,
+				{"[id]", "the ID of the Pokemon to use the item on, shown by party and box"},
+			},
+			examples: []string{"use potion 3", "use fire-stone 5", "use super potion"},
+			minArgs:  1,
+			maxArgs:  -1,
+			complete: firstArg(inventoryItemNames),
+			callback: commandUse,
+		},
+		"shop": {
+			name:        "shop",
+			description: "Lists the items for sale and their prices",
+			category:    categoryCollection,
+			callback:    commandShop,
+		},
+		"buy": {
+			name:        "buy",
+			description: "Buys items from the shop",
+			category:    categoryCollection,
+			arguments:   []commandArg{argItem, {"[qty]", "how many to buy, 1 by default"}},
+			examples:    []string{"buy poke-ball 10", "buy great ball"},
+			minArgs:     1,
+			maxArgs:     -1,
+			complete:    firstArg(shopItemNames),
+			callback:    commandBuy,
+		},
+		"sell": {
+			name:        "sell",
+			description: "Sells items from your bag for half their price",
+			category:    categoryCollection,
+			arguments:   []commandArg{argItem, {"[qty]", "how many to sell, 1 by default"}},
+			examples:    []string{"sell potion 2"},
+			minArgs:     1,
+			maxArgs:     -1,
+			complete:    firstArg(inventoryItemNames),
+			callback:    commandSell,
+		},
+		"money": {
+			name:        "money",
+			description: "Displays your PokeDollar balance",
+			category:    categoryCollection,
+			callback:    commandMoney,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Battles the wild Pokemon in front of you, or a trainer's Pokemon",
+			category:    categoryBattle,
+			arguments: []commandArg{
+				{"[<pokemon_name|#number>]", "the trainer's Pokemon, the wild Pokemon around by default"},
+				{"[level]", "the level of the trainer's Pokemon, 1 to 100"},
+			},
+			examples: []string{"battle", "battle onix 14"},
+			maxArgs:  2,
+			complete: firstArg(pokemonNames),
+			callback: commandBattle,
+		},
+		"fight": {
+			name:        "fight",
+			description: "In battle, attacks with one of your Pokemon's moves",
+			category:    categoryBattle,
+			arguments:   []commandArg{{"[move]", "the move to use, the moves are listed when none is given"}},
+			examples:    []string{"fight", "fight thunder-shock"},
+			maxArgs:     -1,
+			complete:    firstArg(battleMoveNames),
+			callback:    commandFight,
+		},
+		"switch": {
+			name:        "switch",
+			description: "In battle, sends out another Pokemon of your team",
+			category:    categoryBattle,
+			arguments:   []commandArg{{"[pokemon_name]", "the Pokemon to send out, the team is listed when none is given"}},
+			examples:    []string{"switch", "switch pidgey"},
+			maxArgs:     -1,
+			complete:    firstArg(battleTeamNames),
+			callback:    commandSwitch,
+		},
+		"run": {
+			name:        "run",
+			description: "In battle, tries to flee from a wild Pokemon",
+			category:    categoryBattle,
+			callback:    commandRun,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Shows type effectiveness against a Pokemon, with its weaknesses, resistances and immunities",
+			category:    categoryBattle,
+			arguments: []commandArg{
+				{"<attacker-type|pokemon|#number>", "an attacking type, or a Pokemon attacking with each of its types"},
+				{"<defender-pokemon|#number>", "the defending Pokemon"},
+			},
+			examples: []string{"matchup electric gyarados", "matchup pikachu #130"},
+			minArgs:  2,
+			maxArgs:  2,
+			complete: completeMatchup,
+			callback: commandMatchup,
+		},
+		"gen": {
+			name:        "gen",
+			description: "Shows or sets the generation whose types and abilities inspect, matchup and battle use",
+			category:    categorySystem,
+			arguments:   []commandArg{{"[1-9|latest]", "the generation to use"}},
+			examples:    []string{"gen", "gen 1", "gen latest"},
+			maxArgs:     1,
+			callback:    commandGen,
+		},
+		"party": {
+			name:        "party",
+			description: "Lists the Pokemon of your party, in battle order",
+			category:    categoryCollection,
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box",
+			description: "Lists the Pokemon stored in a PC box",
+			category:    categoryCollection,
+			arguments:   []commandArg{{"[n]", "the box to list, the first one by default"}},
+			examples:    []string{"box", "box 2"},
+			maxArgs:     1,
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Moves a Pokemon from your party to the PC",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID},
+			examples:    []string{"deposit 3"},
+			minArgs:     1,
+			maxArgs:     1,
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Moves a Pokemon from the PC to your party",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID},
+			examples:    []string{"withdraw 3"},
+			minArgs:     1,
+			maxArgs:     1,
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap",
+			description: "Swaps the places of two Pokemon in your party or the PC",
+			category:    categoryCollection,
+			arguments: []commandArg{
+				{"<id>", "the ID of the first Pokemon, shown by party and box"},
+				{"<id>", "the ID of the Pokemon to swap it with"},
+			},
+			examples: []string{"swap 3 1"},
+			minArgs:  2,
+			maxArgs:  2,
+			callback: commandSwap,
+		},
+		"release": {
+			name:        "release",
+			description: "Releases a Pokemon for good",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID},
+			examples:    []string{"release 3"},
+			minArgs:     1,
+			maxArgs:     1,
+			callback:    commandRelease,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Gives a Pokemon a nickname, or removes it",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID, {"[name]", "the nickname, case kept; none removes the nickname"}},
+			examples:    []string{"nickname 3 Sparky", "nickname 3 \"Mr. Sparky\"", "nickname 3"},
+			minArgs:     1,
+			maxArgs:     -1,
+			keepCase:    true,
+			callback:    commandNickname,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Evolves a caught Pokemon with an item from your bag, by trading it, or when its level-up conditions are met",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID, {"[item|trade]", "an evolution item from your bag, or trade to trade the Pokemon"}},
+			examples:    []string{"evolve 3", "evolve 3 thunder-stone", "evolve 5 trade"},
+			minArgs:     1,
+			maxArgs:     2,
+			complete:    completeEvolve,
+			callback:    commandEvolve,
+		},
+		"give": {
+			name:        "give",
+			description: "Lets a caught Pokemon hold an item from your bag",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID, argItem},
+			examples:    []string{"give 3 everstone"},
+			minArgs:     2,
+			maxArgs:     -1,
+			complete:    secondArg(inventoryItemNames),
+			callback:    commandGive,
+		},
+		"take": {
+			name:        "take",
+			description: "Takes back the item held by a caught Pokemon",
+			category:    categoryCollection,
+			arguments:   []commandArg{argID},
+			examples:    []string{"take 3"},
+			minArgs:     1,
+			maxArgs:     1,
+			callback:    commandTake,
+		},
+		"regions": {
+			name:        "regions",
+			description: "Lists the regions with their generation, version groups and Pokedexes, or the locations of one region",
+			category:    categoryNavigation,
+			arguments:   []commandArg{{"[region]", "the region to list the locations of"}},
+			examples:    []string{"regions", "regions johto"},
+			maxArgs:     1,
+			callback:    commandRegions,
+		},
+		"pokedex": {
+			name:        "pokedex",
+			description: "Lists the species seen and caught in national dex order, or the completion overall, per generation and per regional dex",
+			category:    categoryCollection,
+			arguments:   []commandArg{{"[completion]", "show the completion instead of the species"}},
+			flags: []commandFlag{
+				{"region", "<region>", "list the species of the regional Pokedex of region, in its order"},
+				{"dex", "<name|number>", "with --region, the Pokedex of the region to list, by name or number, the first one by default"},
+			},
+			examples: []string{"pokedex", "pokedex completion", "pokedex --region johto", "pokedex --region kalos --dex kalos-coastal"},
+			maxArgs:  1,
+			complete: firstArg(pokedexOptions),
+			callback: commandPokedex,
+		},
+		"search": {
+			name:        "search",
+			description: "Lists the Pokemon, location areas, moves and items whose name starts with or contains some text",
+			category:    categorySystem,
+			arguments:   []commandArg{{"<text>", "the text to look for"}},
+			examples:    []string{"search char", "search stone"},
+			minArgs:     1,
+			maxArgs:     -1,
+			callback:    commandSearch,
+		},
+		"alias": {
+			name:        "alias",
+			description: "Lists the aliases, or defines a short name standing for a command line, kept across sessions",
+			category:    categorySystem,
+			arguments: []commandArg{
+				{"[name[=command]]", "the alias to define or show, and the command line it stands for; the arguments typed after the alias are added to it"},
+			},
+			examples: []string{"alias", "alias k=catch", "alias gb='buy great-ball'"},
+			rawArgs:  true,
+			callback: commandAlias,
+		},
+		"unalias": {
+			name:        "unalias",
+			description: "Removes an alias",
+			category:    categorySystem,
+			arguments:   []commandArg{{"<name>", "the alias to remove"}},
+			examples:    []string{"unalias k"},
+			minArgs:     1,
+			maxArgs:     1,
+			complete:    firstArg(aliasNames),
+			callback:    commandUnalias,
+		},
+		"macro": {
+			name:        "macro",
+			description: "Lists the macros, or defines a name running several commands in turn, kept across sessions",
+			category:    categorySystem,
+			arguments: []commandArg{
+				{"[name]", "the macro to define or show"},
+				{"[= command; command...]", "the command lines to run, separated by semicolons. $1, $2... are replaced by the arguments of the macro, $@ by all of them"},
+			},
+			examples: []string{"macro", "macro hunt = explore $1; catch $2", "hunt viridian-forest-area pikachu"},
+			rawArgs:  true,
+			callback: commandMacro,
+		},
+		"unmacro": {
+			name:        "unmacro",
+			description: "Removes a macro",
+			category:    categorySystem,
+			arguments:   []commandArg{{"<name>", "the macro to remove"}},
+			examples:    []string{"unmacro hunt"},
+			minArgs:     1,
+			maxArgs:     1,
+			complete:    firstArg(macroNames),
+			callback:    commandUnmacro,
+		},
+		"history": {
+			name:        "history",
+			description: "Lists the last n commands entered, or all of them. Run one again with !<number>, or !! for the last one",
+			category:    categorySystem,
+			arguments:   []commandArg{{"[n]", "how many of the last commands to list"}},
+			examples:    []string{"history", "history 10", "!12", "!!"},
+			maxArgs:     1,
+			callback:    commandHistory,
+		},
+	}
+}
+
+func commandExit(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	fmt.Println("Closing the Pokedex... Goodbye!")
+	os.Exit(0)
+	return nil // This line will never execute due to os.Exit
+}
+
+// commandHelp lists the commands by category, or shows the usage,
+// arguments, flags and examples of one command. Usage: help [command]
+func commandHelp(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 1 {
+		command, ok := lookupCommand(args[0])
+		if !ok {
+			return unknownCommandError(args[0])
+		}
+		printCommandHelp(command)
+		return nil
+	}
+
+	width := 0
+	for _, command := range commandLists {
+		width = max(width, len(command.label()))
+	}
+
+	fmt.Println("Welcome to the Pokedex!")
+	for _, category := range categories {
+		var names []string
+		for name, command := range commandLists {
+			if command.category == category {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+
+		fmt.Printf("\n%s:\n", category)
+		for _, name := range names {
+			command := commandLists[name]
+			lines := wrapText(command.description, helpWidth-width-4)
+			fmt.Printf("  %-*s  %s\n", width, command.label(), lines[0])
+			for _, line := range lines[1:] {
+				fmt.Printf("  %-*s  %s\n", width, "", line)
+			}
+		}
+	}
+	fmt.Println("\nSee how to use a command with: help <command>")
+
+	return nil
+}
+
+// label returns the name of the command followed by its aliases, e.g.
+// "inspect (i)"
+func (c cliCommand) label() string {
+	if len(c.aliases) == 0 {
+		return c.name
+	}
+	return fmt.Sprintf("%s (%s)", c.name, strings.Join(c.aliases, ", "))
+}
+
+// printCommandHelp shows the usage, arguments, flags and examples of command
+func printCommandHelp(command cliCommand) {
+	fmt.Printf("Usage: %s\n", command.usageLine())
+	if len(command.aliases) > 0 {
+		fmt.Printf("Aliases: %s\n", strings.Join(command.aliases, ", "))
+	}
+	fmt.Println()
+	for _, line := range wrapText(command.description, helpWidth) {
+		fmt.Println(line)
+	}
+
+	if len(command.arguments) > 0 {
+		fmt.Println("\nArguments:")
+		for _, arg := range command.arguments {
+			fmt.Printf("  %s\n", arg.name)
+			for _, line := range wrapText(arg.description, helpWidth-6) {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+
+	if len(command.flags) > 0 {
+		fmt.Println("\nFlags:")
+		for _, f := range command.flags {
+			fmt.Printf("  %s\n", strings.TrimSpace("--"+f.name+" "+f.value))
+			for _, line := range wrapText(f.description, helpWidth-6) {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+
+	if len(command.examples) > 0 {
+		fmt.Println("\nExamples:")
+		for _, example := range command.examples {
+			fmt.Printf("  %s\n", example)
+		}
+	}
+}
+
+// wrapText splits text into lines of at most width characters, breaking
+// between words. Words longer than width get a line of their own.
+func wrapText(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
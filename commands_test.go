@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+	for name, command := range commands() {
+		if command.name != name {
+			t.Errorf("%s: named %q", name, command.name)
+		}
+		if !slices.Contains(categories, command.category) {
+			t.Errorf("%s: unknown category %q", name, command.category)
+		}
+		if command.callback == nil {
+			t.Errorf("%s: no callback", name)
+		}
+		if (command.maxArgs != 0 || command.rawArgs) && len(command.arguments) == 0 {
+			t.Errorf("%s: takes arguments but none are documented", name)
+		}
+		if command.maxArgs > 0 && len(command.arguments) > command.maxArgs {
+			t.Errorf("%s: %d arguments documented but at most %d taken", name, len(command.arguments), command.maxArgs)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	cases := []struct {
+		text  string
+		width int
+		lines []string
+	}{
+		{"", 10, []string{""}},
+		{"fishes in the area", 80, []string{"fishes in the area"}},
+		{"fishes in the area", 10, []string{"fishes in", "the area"}},
+		{"a viridian-forest-area", 5, []string{"a", "viridian-forest-area"}},
+	}
+	for _, c := range cases {
+		if lines := wrapText(c.text, c.width); !slices.Equal(lines, c.lines) {
+			t.Errorf("%q at %d: expected %q, got %q", c.text, c.width, c.lines, lines)
+		}
+	}
+}
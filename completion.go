@@ -0,0 +1,231 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// completer returns what the next argument of a command can complete to,
+// given the arguments already typed
+type completer func(config *pokeapi.Config, args []string) []string
+
+// firstArg completes the first argument of a command with names
+func firstArg(names func(config *pokeapi.Config) []string) completer {
+	return func(config *pokeapi.Config, args []string) []string {
+		if len(args) > 0 {
+			return nil
+		}
+		return names(config)
+	}
+}
+
+// secondArg completes the second argument of a command with names
+func secondArg(names func(config *pokeapi.Config) []string) completer {
+	return func(config *pokeapi.Config, args []string) []string {
+		if len(args) != 1 {
+			return nil
+		}
+		return names(config)
+	}
+}
+
+// completeCatch completes a Pokemon, then "with", then a ball, e.g.
+// catch pikachu with great-ball
+func completeCatch(config *pokeapi.Config, args []string) []string {
+	switch {
+	case len(args) == 0:
+		return pokemonNames(config)
+	case args[len(args)-1] == "with":
+		return ballNames(config)
+	case len(args) == 1:
+		return []string{"with"}
+	}
+	return nil
+}
+
+// completeMatchup completes an attacking type or Pokemon, then a Pokemon
+func completeMatchup(config *pokeapi.Config, args []string) []string {
+	switch len(args) {
+	case 0:
+		return append(slices.Clone(allTypes), pokemonNames(config)...)
+	case 1:
+		return pokemonNames(config)
+	}
+	return nil
+}
+
+// completeEvolve completes the item or trade following the ID
+func completeEvolve(config *pokeapi.Config, args []string) []string {
+	if len(args) != 1 {
+		return nil
+	}
+	return append(inventoryItemNames(config), "trade")
+}
+
+// commandNames returns the names of the commands
+func commandNames(config *pokeapi.Config) []string {
+	return slices.Collect(maps.Keys(commandLists))
+}
+
+// listedAreaNames returns the areas of the last map or areas listing
+func listedAreaNames(config *pokeapi.Config) []string {
+	return listedAreas
+}
+
+// listedLocationNames returns the locations of the last locations listing
+func listedLocationNames(config *pokeapi.Config) []string {
+	return listedLocations
+}
+
+// pokemonNames returns the species in the Pokedex and, once the name index
+// has been built, every Pokemon
+func pokemonNames(config *pokeapi.Config) []string {
+	names := slices.Collect(maps.Values(config.Seen))
+	if idx := readNameIndex(); idx != nil {
+		names = append(names, idx.Names["pokemon"]...)
+	}
+	return names
+}
+
+// inventoryItemNames returns the items in the bag
+func inventoryItemNames(config *pokeapi.Config) []string {
+	var names []string
+	for item, qty := range config.Inventory {
+		if qty > 0 {
+			names = append(names, item)
+		}
+	}
+	return names
+}
+
+// ballNames returns the Poke Balls in the bag
+func ballNames(config *pokeapi.Config) []string {
+	var names []string
+	for _, item := range inventoryItemNames(config) {
+		if supportedItems[item].kind == itemBall {
+			names = append(names, item)
+		}
+	}
+	return names
+}
+
+// shopItemNames returns the items sold in the shop
+func shopItemNames(config *pokeapi.Config) []string {
+	return slices.Collect(maps.Keys(supportedItems))
+}
+
+// rodNames returns the rods fish accepts
+func rodNames(config *pokeapi.Config) []string {
+	return fishingRods
+}
+
+// catchModeNames returns the catch formulas catchmode switches to
+func catchModeNames(config *pokeapi.Config) []string {
+	return []string{catchModeClassic, catchModeAuthentic}
+}
+
+// pokedexOptions returns the arguments of pokedex
+func pokedexOptions(config *pokeapi.Config) []string {
+	return []string{"completion"}
+}
+
+// battleMoveNames returns the moves of the Pokemon in battle
+func battleMoveNames(config *pokeapi.Config) []string {
+	if currentBattle == nil {
+		return nil
+	}
+	var names []string
+	for _, move := range currentBattle.player().moves {
+		names = append(names, move.name)
+	}
+	return names
+}
+
+// battleTeamNames returns the Pokemon of the team in battle
+func battleTeamNames(config *pokeapi.Config) []string {
+	if currentBattle == nil {
+		return nil
+	}
+	var names []string
+	for _, member := range currentBattle.team {
+		names = append(names, member.name())
+	}
+	return names
+}
+
+// completeInput returns where the word being typed at the end of line
+// starts, and the names it could complete to: a command for the first
+// word, then a --flag or an argument of the command
+func completeInput(config *pokeapi.Config, line string) (int, []string) {
+	start := strings.LastIndex(line, " ") + 1
+	// The words before are split as the command will be, a space in an
+	// unterminated quote leaves nothing to complete
+	words, err := splitArgs(line[:start])
+	if err != nil {
+		return start, nil
+	}
+	if len(words) == 0 {
+		return start, append(commandNames(config), userNames(config)...)
+	}
+	words[0] = strings.ToLower(words[0])
+
+	// An alias completes as the command line it stands for
+	if _, isCommand := lookupCommand(words[0]); !isCommand {
+		if value, ok := userSettings.Aliases[words[0]]; ok {
+			aliased, err := splitArgs(value)
+			if err != nil || len(aliased) == 0 {
+				return start, nil
+			}
+			aliased[0] = strings.ToLower(aliased[0])
+			words = append(aliased, words[1:]...)
+		}
+	}
+
+	command, ok := lookupCommand(words[0])
+	if !ok {
+		return start, nil
+	}
+
+	if strings.HasPrefix(line[start:], "--") {
+		var names []string
+		for _, f := range command.flags {
+			names = append(names, "--"+f.name)
+		}
+		return start, names
+	}
+
+	if command.complete == nil {
+		return start, nil
+	}
+	args := withoutFlags(command, words[1:])
+	if !command.keepCase {
+		for i := range args {
+			args[i] = strings.ToLower(args[i])
+		}
+	}
+	return start, command.complete(config, args)
+}
+
+// withoutFlags returns the arguments among words, dropping the flags of
+// command and their values as parseArgs does
+func withoutFlags(command cliCommand, words []string) []string {
+	var args []string
+	for i := 0; i < len(words); i++ {
+		if words[i] == "--" {
+			return append(args, words[i+1:]...)
+		}
+		name, isFlag := strings.CutPrefix(words[i], "--")
+		if !isFlag {
+			args = append(args, words[i])
+			continue
+		}
+		name, _, hasValue := strings.Cut(name, "=")
+		if f, ok := command.flag(strings.ToLower(name)); ok && f.value != "" && !hasValue {
+			i++
+		}
+	}
+	return args
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestCompleteInput(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	original := commandLists
+	t.Cleanup(func() { commandLists = original })
+	all := commands()
+	commandLists = map[string]cliCommand{"catch": all["catch"], "explore": all["explore"], "evolve": all["evolve"], "pokedex": all["pokedex"]}
+
+	originalSettings := userSettings
+	t.Cleanup(func() { userSettings = originalSettings })
+	userSettings = userConfig{Aliases: map[string]string{"k": "catch"}, Macros: map[string][]string{}}
+
+	originalAreas := listedAreas
+	t.Cleanup(func() { listedAreas = originalAreas })
+	listedAreas = []string{"viridian-forest-area"}
+
+	config := &pokeapi.Config{
+		Seen:      map[int]string{25: "pikachu"},
+		Inventory: map[string]int{"potion": 1, "great-ball": 2, "poke-ball": 0},
+	}
+
+	cases := []struct {
+		line       string
+		start      int
+		candidates []string
+	}{
+		{"ca", 0, []string{"catch", "evolve", "explore", "k", "pokedex"}},
+		{"k pika", 2, []string{"pikachu"}},
+		{"c pika", 2, []string{"pikachu"}},
+		{"explore vir", 8, []string{"viridian-forest-area"}},
+		{"catch pika", 6, []string{"pikachu"}},
+		{"catch pikachu w", 14, []string{"with"}},
+		{"catch pikachu with gr", 19, []string{"great-ball"}},
+		{"evolve 3 t", 9, []string{"great-ball", "potion", "trade"}},
+		{"pokedex --re", 8, []string{"--dex", "--region"}},
+		{"pokedex --region johto co", 23, []string{"completion"}},
+		{"pokedex --region=johto co", 23, []string{"completion"}},
+		{"pokedex -- co", 11, []string{"completion"}},
+		{"CATCH 'pikachu' w", 16, []string{"with"}},
+		{"catch \"pikachu\" with gr", 21, []string{"great-ball"}},
+		{"catch \"mr mime", 10, nil},
+		{"inspect 1", 8, nil},
+	}
+	for _, c := range cases {
+		start, candidates := completeInput(config, c.line)
+		slices.Sort(candidates)
+		if start != c.start || !slices.Equal(candidates, c.candidates) {
+			t.Errorf("%q: expected %d %v, got %d %v", c.line, c.start, c.candidates, start, candidates)
+		}
+	}
+}
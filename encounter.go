@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// maxSteps is how many steps walk/fish/surf take before giving up on an encounter
+const maxSteps = 10
+
+// fishingRods are the encounter methods accepted by the fish command
+var fishingRods = []string{"old-rod", "good-rod", "super-rod"}
+
+// wildPokemon is a Pokemon that appeared in the current area
+type wildPokemon struct {
+	name   string
+	number int // national dex number, 0 for alternate forms
+	level  int
+}
+
+// wild is the Pokemon currently in front of the player, nil when there is none
+var wild *wildPokemon
+
+// encounterRate returns the per-step encounter rate of method in area for version.
+// An empty version picks the most recent version the method is available in.
+func encounterRate(area *pokeapi.ExploreAreaEncounter, method, version string) (rate int, usedVersion string, ok bool) {
+	for _, methodRate := range area.EncounterMethodRates {
+		if methodRate.EncounterMethod.Name != method {
+			continue
+		}
+		for _, detail := range methodRate.VersionDetails {
+			if version == "" || detail.Version.Name == version {
+				rate, usedVersion, ok = detail.Rate, detail.Version.Name, true
+			}
+		}
+	}
+
+	return rate, usedVersion, ok
+}
+
+// rollWildPokemon picks a Pokemon met with method in version, weighted by the
+// encounter chance, at a level rolled within the encounter's level range
+func rollWildPokemon(r *rand.Rand, area *pokeapi.ExploreAreaEncounter, method, version string) (*wildPokemon, bool) {
+	type candidate struct {
+		pokemon pokeapi.NamedAPIResource
+		detail  pokeapi.EncounterDetail
+	}
+
+	var candidates []candidate
+	totalChance := 0
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if versionDetail.Version.Name != version {
+				continue
+			}
+			for _, detail := range versionDetail.EncounterDetails {
+				if detail.Method.Name != method || detail.Chance <= 0 {
+					continue
+				}
+				candidates = append(candidates, candidate{pokemon: encounter.Pokemon, detail: detail})
+				totalChance += detail.Chance
+			}
+		}
+	}
+
+	if totalChance == 0 {
+		return nil, false
+	}
+
+	roll := r.Intn(totalChance)
+	for _, c := range candidates {
+		if roll < c.detail.Chance {
+			level := c.detail.MinLevel
+			if c.detail.MaxLevel > c.detail.MinLevel {
+				level += r.Intn(c.detail.MaxLevel - c.detail.MinLevel + 1)
+			}
+			return &wildPokemon{name: c.pokemon.Name, number: c.pokemon.ID(), level: level}, true
+		}
+		roll -= c.detail.Chance
+	}
+
+	return nil, false
+}
+
+// catchLevel returns the level of a Pokemon caught outside of a battle: the
+// level of the wild Pokemon in front of the player, or a level rolled within
+// the levels it is met at in the current area
+func catchLevel(client *pokeapi.Client, config *pokeapi.Config, r *rand.Rand, pokemonName string) (int, error) {
+	if wild != nil && wild.name == pokemonName {
+		return wild.level, nil
+	}
+	if config.Location == "" {
+		return defaultCatchLevel, nil
+	}
+
+	area, err := client.Explore(config.Location)
+	if err != nil {
+		return 0, err
+	}
+
+	minLevel, maxLevel := 0, 0
+	for _, encounter := range area.PokemonEncounters {
+		if encounter.Pokemon.Name != pokemonName {
+			continue
+		}
+		for _, versionDetail := range encounter.VersionDetails {
+			for _, detail := range versionDetail.EncounterDetails {
+				if minLevel == 0 || detail.MinLevel < minLevel {
+					minLevel = detail.MinLevel
+				}
+				maxLevel = max(maxLevel, detail.MaxLevel)
+			}
+		}
+	}
+	if minLevel == 0 {
+		return defaultCatchLevel, nil
+	}
+
+	return minLevel + r.Intn(max(maxLevel-minLevel, 0)+1), nil
+}
+
+// searchWildPokemon walks the current area using method until a wild Pokemon appears
+func searchWildPokemon(client *pokeapi.Client, config *pokeapi.Config, method, action string) error {
+	if config.Location == "" {
+		return fmt.Errorf("you are not in any area. Travel somewhere first with: goto <area_name>")
+	}
+
+	area, err := client.Explore(config.Location)
+	if err != nil {
+		return err
+	}
+
+	rate, version, ok := encounterRate(area, method, config.Version)
+	if !ok {
+		return fmt.Errorf("you can't %s in %s", action, area.Name)
+	}
+
+	// Create a new random source r with current time
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for step := 1; step <= maxSteps; step++ {
+		if r.Intn(100) >= rate {
+			continue
+		}
+
+		pokemon, found := rollWildPokemon(r, area, method, version)
+		if !found {
+			break
+		}
+
+		wild = pokemon
+		registerSeen(config, wild.number, wild.name)
+		fmt.Printf("A wild %s (Lv. %d) appeared! (%s, %d steps)\n", wild.name, wild.level, version, step)
+		fmt.Println("You may now catch it with the catch command.")
+		return nil
+	}
+
+	fmt.Println("Nothing appeared... keep trying!")
+
+	return nil
+}
+
+// commandWalk looks for wild Pokemon in the tall grass of the current area
+func commandWalk(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	return searchWildPokemon(client, config, "walk", "walk")
+}
+
+// commandSurf looks for wild Pokemon on the water of the current area
+func commandSurf(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	return searchWildPokemon(client, config, "surf", "surf")
+}
+
+// commandFish fishes in the current area. Usage: fish [old-rod|good-rod|super-rod]
+func commandFish(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	rod := fishingRods[0]
+	if len(args) == 1 {
+		rod = args[0]
+	}
+
+	for _, r := range fishingRods {
+		if r == rod {
+			return searchWildPokemon(client, config, rod, "fish with the "+rod)
+		}
+	}
+
+	return fmt.Errorf("unknown rod %q. Usage: %s", rod, commandLists["fish"].usageLine())
+}
+
+// commandVersion shows or sets the game version used for encounters. Usage: version [name]
+func commandVersion(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		if config.Version == "" {
+			fmt.Println("Version: latest available in each area")
+		} else {
+			fmt.Printf("Version: %s\n", config.Version)
+		}
+		return nil
+	}
+
+	version := args[0]
+	if version == "latest" {
+		version = ""
+	} else {
+		versions, err := gameVersions(client)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(versions, version) {
+			err := fmt.Errorf("there is no version named %s", version)
+			return suggestAmong(client, "version", version, versions, err)
+		}
+	}
+	config.Version = version
+	fmt.Println("Version updated.")
+
+	return nil
+}
+
+// gameVersions returns the names of the game versions, e.g. red or x
+func gameVersions(client *pokeapi.Client) ([]string, error) {
+	var names []string
+	for resource, err := range client.Resources("version", pokeapi.ListOptions{}) {
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, resource.Name)
+	}
+	return names, nil
+}
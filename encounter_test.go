@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const testArea = `{
+	"name": "route-1-area",
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk"}, "version_details": [
+			{"rate": 25, "version": {"name": "red"}},
+			{"rate": 30, "version": {"name": "blue"}}
+		]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "pidgey"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 2, "max_level": 5, "chance": 100, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "magikarp"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 5, "max_level": 5, "chance": 100, "method": {"name": "old-rod"}}
+			]}
+		]}
+	]
+}`
+
+func TestEncounterRate(t *testing.T) {
+	var area pokeapi.ExploreAreaEncounter
+	if err := json.Unmarshal([]byte(testArea), &area); err != nil {
+		t.Fatal(err)
+	}
+
+	if rate, version, ok := encounterRate(&area, "walk", "red"); !ok || rate != 25 || version != "red" {
+		t.Errorf("expected rate 25 in red, got %d in %s (ok=%v)", rate, version, ok)
+	}
+	if rate, version, ok := encounterRate(&area, "walk", ""); !ok || rate != 30 || version != "blue" {
+		t.Errorf("expected latest version blue with rate 30, got %d in %s (ok=%v)", rate, version, ok)
+	}
+	if _, _, ok := encounterRate(&area, "surf", ""); ok {
+		t.Errorf("expected no surf encounters")
+	}
+}
+
+func TestRollWildPokemon(t *testing.T) {
+	var area pokeapi.ExploreAreaEncounter
+	if err := json.Unmarshal([]byte(testArea), &area); err != nil {
+		t.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		pokemon, ok := rollWildPokemon(r, &area, "walk", "red")
+		if !ok || pokemon.name != "pidgey" {
+			t.Fatalf("expected a pidgey, got %+v", pokemon)
+		}
+		if pokemon.level < 2 || pokemon.level > 5 {
+			t.Errorf("level %d out of range 2-5", pokemon.level)
+		}
+	}
+
+	if _, ok := rollWildPokemon(r, &area, "walk", "blue"); ok {
+		t.Errorf("expected no Pokemon for a version without encounter details")
+	}
+}
+
+func TestCommandVersion(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/version": `{"count": 3, "results": [{"name": "red"}, {"name": "blue"}, {"name": "gold"}]}`,
+	})
+	config := &pokeapi.Config{}
+
+	if err := commandVersion(client, config, []string{"blue"}, nil); err != nil || config.Version != "blue" {
+		t.Fatalf("expected the version to be set to blue, got %q and %v", config.Version, err)
+	}
+
+	err := commandVersion(client, config, []string{"redd"}, nil)
+	if err == nil || err.Error() != "there is no version named redd. Did you mean red?" {
+		t.Errorf("expected a suggestion for redd, got %v", err)
+	}
+	if config.Version != "blue" {
+		t.Errorf("expected the version to be kept, got %q", config.Version)
+	}
+
+	if err := commandVersion(client, config, []string{"latest"}, nil); err != nil || config.Version != "" {
+		t.Errorf("expected the latest version, got %q and %v", config.Version, err)
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// maxHappiness is the highest friendship a Pokemon can reach
+	maxHappiness = 255
+	// defaultHappiness is the base happiness of most species
+	defaultHappiness = 70
+	// levelUpHappiness is the friendship gained on each level-up
+	levelUpHappiness = 5
+	// everstone prevents the Pokemon holding it from evolving
+	everstone = "everstone"
+)
+
+// Evolution triggers, as named by the /evolution-trigger endpoint
+const (
+	triggerLevelUp = "level-up"
+	triggerUseItem = "use-item"
+	triggerTrade   = "trade"
+)
+
+// confirm asks the player a yes/no question, yes being the default
+var confirm = func(question string) bool {
+	answer, err := stdin.Ask(question + " [Y/n] ")
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
+
+// now returns the current time, used for time-of-day evolutions
+var now = time.Now
+
+// matchesTimeOfDay reports whether t falls in timeOfDay ("day", "night" or
+// "dusk"). An empty timeOfDay matches any time.
+func matchesTimeOfDay(timeOfDay string, t time.Time) bool {
+	hour := t.Hour()
+	switch timeOfDay {
+	case "":
+		return true
+	case "day":
+		return hour >= 6 && hour < 18
+	case "night":
+		return hour < 6 || hour >= 18
+	case "dusk":
+		return hour == 17
+	}
+	return false
+}
+
+// evolutionConditionsMet reports whether owned meets every condition of
+// detail when evolution is triggered by trigger, using item for use-item.
+// Conditions the game doesn't model (beauty, affection, party, location...)
+// are never met.
+func evolutionConditionsMet(detail pokeapi.EvolutionDetail, owned *pokeapi.OwnedPokemon, trigger, item string, t time.Time) bool {
+	if detail.Trigger.Name != trigger {
+		return false
+	}
+	if detail.Item != nil && detail.Item.Name != item {
+		return false
+	}
+	if detail.HeldItem != nil && detail.HeldItem.Name != owned.HeldItem {
+		return false
+	}
+	if detail.MinLevel != nil && owned.Level < *detail.MinLevel {
+		return false
+	}
+	if detail.MinHappiness != nil && owned.Happiness < *detail.MinHappiness {
+		return false
+	}
+	if !matchesTimeOfDay(detail.TimeOfDay, t) {
+		return false
+	}
+	if detail.Gender != nil {
+		// PokeAPI genders: 1 is female, 2 is male
+		if (*detail.Gender == 1) != (owned.Gender == "female") {
+			return false
+		}
+	}
+	if detail.KnownMove != nil && !knowsMove(owned, detail.KnownMove.Name) {
+		return false
+	}
+
+	unsupported := detail.KnownMoveType != nil || detail.Location != nil ||
+		detail.PartySpecies != nil || detail.PartyType != nil || detail.TradeSpecies != nil ||
+		detail.MinBeauty != nil || detail.MinAffection != nil || detail.RelativePhysicalStats != nil ||
+		detail.NeedsOverworldRain || detail.TurnUpsideDown
+
+	return !unsupported
+}
+
+// knowsMove reports whether owned knows moveName
+func knowsMove(owned *pokeapi.OwnedPokemon, moveName string) bool {
+	for _, m := range owned.Moves {
+		if m == moveName {
+			return true
+		}
+	}
+	return false
+}
+
+// findChainLink returns the link of species in the evolution chain starting at link
+func findChainLink(link *pokeapi.EvolutionChainLink, species string) *pokeapi.EvolutionChainLink {
+	if link.Species.Name == species {
+		return link
+	}
+	for i := range link.EvolvesTo {
+		if found := findChainLink(&link.EvolvesTo[i], species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// evolutionTarget returns the species owned evolves into when trigger
+// happens, and the detail that allowed it. ok is false when it can't evolve.
+func evolutionTarget(client *pokeapi.Client, owned *pokeapi.OwnedPokemon, trigger, item string) (target string, detail pokeapi.EvolutionDetail, ok bool, err error) {
+	if owned.HeldItem == everstone {
+		return "", detail, false, nil
+	}
+
+	species, err := client.GetPokemonSpecies(owned.Pokemon.Species.Name)
+	if err != nil {
+		return "", detail, false, err
+	}
+	if species.EvolutionChain.URL == "" {
+		return "", detail, false, nil
+	}
+
+	chain, err := client.GetEvolutionChain(species.EvolutionChain.URL)
+	if err != nil {
+		return "", detail, false, err
+	}
+
+	link := findChainLink(&chain.Chain, species.Name)
+	if link == nil {
+		return "", detail, false, nil
+	}
+
+	t := now()
+	for _, next := range link.EvolvesTo {
+		for _, d := range next.EvolutionDetails {
+			if evolutionConditionsMet(d, owned, trigger, item, t) {
+				return next.Species.Name, d, true, nil
+			}
+		}
+	}
+
+	return "", detail, false, nil
+}
+
+// evolve turns owned into the default form of species, keeping its level,
+// IVs, EVs, nature and moves. Its stats are recalculated, it learns the
+// moves the new species knows at its level and the Pokedex registers it.
+func evolve(client *pokeapi.Client, config *pokeapi.Config, owned *pokeapi.OwnedPokemon, species string) error {
+	newSpecies, err := client.GetPokemonSpecies(species)
+	if err != nil {
+		return err
+	}
+
+	pokemonName := newSpecies.Name
+	for _, v := range newSpecies.Varieties {
+		if v.IsDefault {
+			pokemonName = v.Pokemon.Name
+			break
+		}
+	}
+
+	pokemon, err := client.Catch(pokemonName)
+	if err != nil {
+		return err
+	}
+
+	oldName := owned.Name()
+	owned.Pokemon = pokemon.Summary()
+	if err := refreshStats(client, owned); err != nil {
+		return err
+	}
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", oldName, pokemon.Name)
+	registerCaught(config, owned.Pokemon)
+
+	moves, err := loadBattleMoves(client, movesLearnedAt(pokemon, owned.Level))
+	if err != nil {
+		return err
+	}
+	for _, m := range moves {
+		learnMove(owned, m.name)
+	}
+
+	return nil
+}
+
+// tryEvolve evolves owned if trigger lets it, after asking the player, who
+// may cancel. It reports whether owned evolved. A required held item is
+// used up by the evolution.
+func tryEvolve(client *pokeapi.Client, config *pokeapi.Config, owned *pokeapi.OwnedPokemon, trigger, item string) (bool, error) {
+	target, detail, ok, err := evolutionTarget(client, owned, trigger, item)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	fmt.Printf("What? %s is evolving!\n", owned.Name())
+	if !confirm(fmt.Sprintf("Let %s evolve into %s?", owned.Name(), target)) {
+		fmt.Printf("Huh? %s stopped evolving!\n", owned.Name())
+		return false, nil
+	}
+
+	if err := evolve(client, config, owned, target); err != nil {
+		return false, err
+	}
+	if detail.HeldItem != nil {
+		owned.HeldItem = ""
+	}
+
+	return true, nil
+}
+
+// commandEvolve evolves a caught Pokemon by using an item on it, trading it,
+// or checking its level-up conditions (friendship, time of day...) again.
+// Usage: evolve <id> [item|trade]
+func commandEvolve(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	trigger, item := triggerLevelUp, ""
+	if len(args) == 2 {
+		if args[1] == triggerTrade {
+			trigger = triggerTrade
+		} else {
+			trigger, item = triggerUseItem, normalizeItemName(args[1])
+			if config.Inventory[item] == 0 {
+				return missingItemError(client, config, item)
+			}
+		}
+	}
+
+	evolved, err := tryEvolve(client, config, owned, trigger, item)
+	if err != nil {
+		return err
+	}
+	if !evolved {
+		fmt.Printf("%s didn't evolve.\n", owned.Name())
+		return nil
+	}
+
+	if item != "" {
+		return removeItem(config, item, 1)
+	}
+
+	return nil
+}
+
+// commandGive lets a caught Pokemon hold an item from the bag. The item it
+// held before goes back to the bag. Usage: give <id> <item>
+func commandGive(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	itemName := normalizeItemName(strings.Join(args[1:], " "))
+	if config.Inventory[itemName] == 0 {
+		return missingItemError(client, config, itemName)
+	}
+	if err := removeItem(config, itemName, 1); err != nil {
+		return err
+	}
+	if owned.HeldItem != "" {
+		addItem(config, owned.HeldItem, 1)
+		fmt.Printf("Took the %s from %s.\n", owned.HeldItem, owned.Name())
+	}
+
+	owned.HeldItem = itemName
+	fmt.Printf("%s is now holding the %s.\n", owned.Name(), itemName)
+
+	return nil
+}
+
+// commandTake puts the item held by a caught Pokemon back in the bag. Usage: take <id>
+func commandTake(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+	if owned.HeldItem == "" {
+		return fmt.Errorf("%s isn't holding anything", owned.Name())
+	}
+
+	addItem(config, owned.HeldItem, 1)
+	fmt.Printf("Took the %s from %s.\n", owned.HeldItem, owned.Name())
+	owned.HeldItem = ""
+
+	return nil
+}
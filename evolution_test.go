@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestEvolutionConditionsMet(t *testing.T) {
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		name    string
+		detail  string
+		owned   pokeapi.OwnedPokemon
+		trigger string
+		item    string
+		at      time.Time
+		met     bool
+	}{
+		{
+			name:    "below min level",
+			detail:  `{"trigger": {"name": "level-up"}, "min_level": 16}`,
+			owned:   pokeapi.OwnedPokemon{Level: 15},
+			trigger: triggerLevelUp,
+			met:     false,
+		},
+		{
+			name:    "at min level",
+			detail:  `{"trigger": {"name": "level-up"}, "min_level": 16}`,
+			owned:   pokeapi.OwnedPokemon{Level: 16},
+			trigger: triggerLevelUp,
+			met:     true,
+		},
+		{
+			name:    "level-up condition on trade",
+			detail:  `{"trigger": {"name": "level-up"}, "min_level": 16}`,
+			owned:   pokeapi.OwnedPokemon{Level: 16},
+			trigger: triggerTrade,
+			met:     false,
+		},
+		{
+			name:    "happiness at night",
+			detail:  `{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}`,
+			owned:   pokeapi.OwnedPokemon{Happiness: 200},
+			trigger: triggerLevelUp,
+			at:      midnight,
+			met:     true,
+		},
+		{
+			name:    "happiness during the day",
+			detail:  `{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}`,
+			owned:   pokeapi.OwnedPokemon{Happiness: 200},
+			trigger: triggerLevelUp,
+			at:      noon,
+			met:     false,
+		},
+		{
+			name:    "not happy enough",
+			detail:  `{"trigger": {"name": "level-up"}, "min_happiness": 160}`,
+			owned:   pokeapi.OwnedPokemon{Happiness: 70},
+			trigger: triggerLevelUp,
+			met:     false,
+		},
+		{
+			name:    "right stone",
+			detail:  `{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}`,
+			trigger: triggerUseItem,
+			item:    "thunder-stone",
+			met:     true,
+		},
+		{
+			name:    "wrong stone",
+			detail:  `{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}`,
+			trigger: triggerUseItem,
+			item:    "fire-stone",
+			met:     false,
+		},
+		{
+			name:    "trade holding the item",
+			detail:  `{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}`,
+			owned:   pokeapi.OwnedPokemon{HeldItem: "metal-coat"},
+			trigger: triggerTrade,
+			met:     true,
+		},
+		{
+			name:    "trade without the item",
+			detail:  `{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}`,
+			trigger: triggerTrade,
+			met:     false,
+		},
+		{
+			name:    "unsupported condition",
+			detail:  `{"trigger": {"name": "level-up"}, "location": {"name": "eterna-forest"}}`,
+			trigger: triggerLevelUp,
+			met:     false,
+		},
+	}
+	for _, c := range cases {
+		var detail pokeapi.EvolutionDetail
+		if err := json.Unmarshal([]byte(c.detail), &detail); err != nil {
+			t.Fatal(err)
+		}
+		if c.at.IsZero() {
+			c.at = noon
+		}
+		if met := evolutionConditionsMet(detail, &c.owned, c.trigger, c.item, c.at); met != c.met {
+			t.Errorf("%s: expected %v, got %v", c.name, c.met, met)
+		}
+	}
+}
+
+func TestTryEvolve(t *testing.T) {
+	routes := map[string]string{
+		"/pokemon-species/charmeleon": `{"name": "charmeleon", "varieties": [{"is_default": true, "pokemon": {"name": "charmeleon"}}]}`,
+		"/pokemon/charmeleon": `{
+			"name": "charmeleon",
+			"species": {"name": "charmeleon"},
+			"stats": [{"base_stat": 58, "stat": {"name": "hp"}}]
+		}`,
+		"/nature/hardy": `{"name": "hardy"}`,
+		"/evolution-chain/2": `{"id": 2, "chain": {
+			"species": {"name": "charmander"},
+			"evolves_to": [{
+				"species": {"name": "charmeleon"},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}]
+			}]
+		}}`,
+	}
+	client := newTestClient(t, routes)
+	routes["/pokemon-species/charmander"] = `{"name": "charmander", "evolution_chain": {"url": "` + client.BaseURL + `/evolution-chain/2"}}`
+
+	original := confirm
+	t.Cleanup(func() { confirm = original })
+
+	owned := &pokeapi.OwnedPokemon{
+		Nickname: "Blaze",
+		Level:    16,
+		Nature:   "hardy",
+		Pokemon:  pokeapi.PokemonSummary{Name: "charmander", Species: pokeapi.NamedAPIResource{Name: "charmander"}},
+	}
+
+	config := &pokeapi.Config{}
+	confirm = func(string) bool { return false }
+	evolved, err := tryEvolve(client, config, owned, triggerLevelUp, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evolved || owned.Pokemon.Name != "charmander" {
+		t.Errorf("expected the evolution to be cancelled, got %s", owned.Pokemon.Name)
+	}
+
+	confirm = func(string) bool { return true }
+	evolved, err = tryEvolve(client, config, owned, triggerLevelUp, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !evolved || owned.Pokemon.Name != "charmeleon" {
+		t.Fatalf("expected charmander to evolve into charmeleon, got %s", owned.Pokemon.Name)
+	}
+	if owned.Nickname != "Blaze" || owned.Level != 16 {
+		t.Errorf("expected the nickname and level to be kept, got %q at level %d", owned.Nickname, owned.Level)
+	}
+	if owned.Stats["hp"] != calcHP(58, 0, 0, 16) {
+		t.Errorf("expected charmeleon's stats, got %d HP", owned.Stats["hp"])
+	}
+
+	// Charmeleon is the end of this chain
+	evolved, err = tryEvolve(client, config, owned, triggerLevelUp, "")
+	if err != nil || evolved {
+		t.Errorf("expected charmeleon not to evolve any further, got %v (%v)", evolved, err)
+	}
+}
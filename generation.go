@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// latestGeneration is the most recent main-series generation
+const latestGeneration = 9
+
+// romanNumerals maps the numerals used in generation names ("generation-iv")
+var romanNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5,
+	"vi": 6, "vii": 7, "viii": 8, "ix": 9,
+}
+
+// generationNumber turns "generation-iii" into 3. Unknown names return 0.
+func generationNumber(name string) int {
+	return romanNumerals[strings.TrimPrefix(name, "generation-")]
+}
+
+// activeGeneration returns the generation selected with the gen command
+func activeGeneration(config *pokeapi.Config) int {
+	if config.Generation == 0 {
+		return latestGeneration
+	}
+	return config.Generation
+}
+
+// typesForGeneration returns the types pokemon had in gen. PokeAPI lists
+// past types as "valid up to and including generation X", so the earliest
+// entry at or after gen wins.
+func typesForGeneration(pokemon pokeapi.PokemonSummary, gen int) []string {
+	types := pokemon.Types
+	best := 0
+	for _, past := range pokemon.PastTypes {
+		pastGen := generationNumber(past.Generation.Name)
+		if pastGen >= gen && (best == 0 || pastGen < best) {
+			best, types = pastGen, past.Types
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+// abilitiesForGeneration returns the abilities pokemon had in gen, ordered
+// by slot. Abilities only exist from generation 3 onwards.
+func abilitiesForGeneration(pokemon *pokeapi.Pokemon, gen int) []pokeapi.PokemonAbility {
+	if gen < 3 {
+		return nil
+	}
+
+	bySlot := make(map[int]pokeapi.PokemonAbility)
+	for _, a := range pokemon.Abilities {
+		bySlot[a.Slot] = a
+	}
+
+	var override *pokeapi.PokemonPastAbility
+	best := 0
+	for i, past := range pokemon.PastAbilities {
+		pastGen := generationNumber(past.Generation.Name)
+		if pastGen >= gen && (best == 0 || pastGen < best) {
+			best, override = pastGen, &pokemon.PastAbilities[i]
+		}
+	}
+	if override != nil {
+		for _, a := range override.Abilities {
+			if a.Ability == nil {
+				// The slot didn't exist back then
+				delete(bySlot, a.Slot)
+				continue
+			}
+			bySlot[a.Slot] = a
+		}
+	}
+
+	abilities := make([]pokeapi.PokemonAbility, 0, len(bySlot))
+	for _, a := range bySlot {
+		abilities = append(abilities, a)
+	}
+	sort.Slice(abilities, func(i, j int) bool {
+		return abilities[i].Slot < abilities[j].Slot
+	})
+
+	return abilities
+}
+
+// commandGen shows or sets the generation used by inspect, matchup and battle. Usage: gen [1-9|latest]
+func commandGen(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		fmt.Printf("Generation: %d\n", activeGeneration(config))
+		return nil
+	}
+
+	if args[0] == "latest" {
+		config.Generation = 0
+		fmt.Printf("Generation set to the latest (%d)\n", latestGeneration)
+		return nil
+	}
+
+	gen, err := strconv.Atoi(args[0])
+	if err != nil || gen < 1 || gen > latestGeneration {
+		return fmt.Errorf("generation must be between 1 and %d. Usage: %s", latestGeneration, commandLists["gen"].usageLine())
+	}
+
+	config.Generation = gen
+	fmt.Printf("Generation set to %d\n", gen)
+
+	return nil
+}
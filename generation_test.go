@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestGenerationNumber(t *testing.T) {
+	cases := map[string]int{
+		"generation-i":    1,
+		"generation-iv":   4,
+		"generation-viii": 8,
+		"unknown":         0,
+	}
+	for name, expected := range cases {
+		if actual := generationNumber(name); actual != expected {
+			t.Errorf("%s: expected %d, got %d", name, expected, actual)
+		}
+	}
+}
+
+func TestTypesAndAbilitiesForGeneration(t *testing.T) {
+	// Clefairy was normal before fairy existed, and gengar had levitate until generation 6
+	var pokemon pokeapi.Pokemon
+	err := json.Unmarshal([]byte(`{
+		"name": "clefairy",
+		"types": [{"slot": 1, "type": {"name": "fairy"}}],
+		"past_types": [{"generation": {"name": "generation-v"}, "types": [{"slot": 1, "type": {"name": "normal"}}]}],
+		"abilities": [
+			{"slot": 1, "is_hidden": false, "ability": {"name": "cute-charm"}},
+			{"slot": 2, "is_hidden": false, "ability": {"name": "magic-guard"}},
+			{"slot": 3, "is_hidden": true, "ability": {"name": "friend-guard"}}
+		],
+		"past_abilities": [{"generation": {"name": "generation-iv"}, "abilities": [
+			{"slot": 2, "is_hidden": false, "ability": null},
+			{"slot": 3, "is_hidden": true, "ability": null}
+		]}]
+	}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if types := typesForGeneration(pokemon.Summary(), 3); len(types) != 1 || types[0] != "normal" {
+		t.Errorf("expected normal in generation 3, got %v", types)
+	}
+	if types := typesForGeneration(pokemon.Summary(), 6); len(types) != 1 || types[0] != "fairy" {
+		t.Errorf("expected fairy in generation 6, got %v", types)
+	}
+
+	if abilities := abilitiesForGeneration(&pokemon, 2); len(abilities) != 0 {
+		t.Errorf("expected no abilities before generation 3, got %v", abilities)
+	}
+	if abilities := abilitiesForGeneration(&pokemon, 4); len(abilities) != 1 || abilities[0].Ability.Name != "cute-charm" {
+		t.Errorf("expected only cute-charm in generation 4, got %v", abilities)
+	}
+	if abilities := abilitiesForGeneration(&pokemon, 5); len(abilities) != 3 {
+		t.Errorf("expected 3 abilities in generation 5, got %v", abilities)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/lineedit"
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	historyFileName = "history"
+	// defaultHistorySize is how many commands the history keeps, unless
+	// set with -history-size
+	defaultHistorySize = 1000
+)
+
+// historyFilePath returns the location of the history file
+func historyFilePath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, historyFileName), nil
+}
+
+// loadHistory adds the commands of the history file at path to history.
+// A missing file is an empty history.
+func loadHistory(path string, history *lineedit.History) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return history.Load(file)
+}
+
+// saveHistory writes history to the history file at path
+func saveHistory(path string, history *lineedit.History) error {
+	var body bytes.Buffer
+	if err := history.Save(&body); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, body.Bytes())
+}
+
+// expandHistory returns the command a "!n" input refers to, n being its
+// number in the history listing, or the last command for "!!". Other
+// inputs are returned as is.
+func expandHistory(history *lineedit.History, input string) (string, error) {
+	ref, isRef := strings.CutPrefix(strings.TrimSpace(input), "!")
+	if !isRef {
+		return input, nil
+	}
+
+	entries := history.Entries()
+	if ref == "!" {
+		if len(entries) == 0 {
+			return "", fmt.Errorf("the history is empty")
+		}
+		return entries[len(entries)-1], nil
+	}
+
+	n, err := strconv.Atoi(ref)
+	if err != nil || n < 1 || n > len(entries) {
+		return "", fmt.Errorf("!%s: no such command in the history. See: history", ref)
+	}
+	return entries[n-1], nil
+}
+
+// commandHistory lists the last n commands, or all of them, numbered to be
+// run again with !n. Usage: history [n]
+func commandHistory(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	entries := stdin.History.Entries()
+	first := 0
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("n must be a positive number. Usage: %s", commandLists["history"].usageLine())
+		}
+		first = max(len(entries)-n, 0)
+	}
+
+	for i := first; i < len(entries); i++ {
+		fmt.Printf("%5d  %s\n", i+1, entries[i])
+	}
+	fmt.Println("Run a command again with: !<number>, or !! for the last one")
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/lineedit"
+)
+
+func TestExpandHistory(t *testing.T) {
+	history := lineedit.NewHistory(defaultHistorySize)
+	history.Add("map")
+	history.Add("catch pikachu")
+
+	cases := map[string]string{
+		"!1":      "map",
+		"!!":      "catch pikachu",
+		" !2 ":    "catch pikachu",
+		"party":   "party",
+		"explore": "explore",
+	}
+	for input, expected := range cases {
+		expanded, err := expandHistory(history, input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+			continue
+		}
+		if expanded != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, expanded)
+		}
+	}
+
+	for _, input := range []string{"!0", "!3", "!map"} {
+		if _, err := expandHistory(history, input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+	if _, err := expandHistory(lineedit.NewHistory(defaultHistorySize), "!!"); err == nil {
+		t.Error("expected an error for an empty history")
+	}
+}
+
+func TestSaveAndLoadHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", historyFileName)
+
+	loaded := lineedit.NewHistory(defaultHistorySize)
+	if err := loadHistory(path, loaded); err != nil || len(loaded.Entries()) != 0 {
+		t.Fatalf("expected an empty history without file, got %v (%v)", loaded.Entries(), err)
+	}
+
+	history := lineedit.NewHistory(defaultHistorySize)
+	history.Add("map")
+	history.Add("nickname 1 Sparky")
+	if err := saveHistory(path, history); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := loadHistory(path, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(loaded.Entries(), history.Entries()) {
+		t.Errorf("expected %v, got %v", history.Entries(), loaded.Entries())
+	}
+}
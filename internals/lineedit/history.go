@@ -0,0 +1,60 @@
+package lineedit
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+)
+
+// History is the list of lines entered, oldest first, recalled with the
+// up and down keys and searched with Ctrl-R
+type History struct {
+	entries []string
+	size    int
+}
+
+// NewHistory returns an empty history keeping the last size lines
+func NewHistory(size int) *History {
+	return &History{size: max(size, 0)}
+}
+
+// Add appends line to the history. Blank lines and a line repeating the
+// last one are ignored, so earlier entries keep their place.
+func (h *History) Add(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == line {
+		return
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.size {
+		h.entries = slices.Delete(h.entries, 0, len(h.entries)-h.size)
+	}
+}
+
+// Entries returns the lines of the history, oldest first
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Load adds the lines read from r to the history, one line per entry
+func (h *History) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		h.Add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Save writes the history to w, one line per entry
+func (h *History) Save(w io.Writer) error {
+	for _, entry := range h.entries {
+		if _, err := io.WriteString(w, entry+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,457 @@
+// Package lineedit reads lines from a terminal with cursor movement,
+// editing keys, history recall and search, and tab completion, falling
+// back to plain line reads when the input is not a terminal.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C
+var ErrInterrupted = errors.New("interrupted")
+
+// Completer returns what the word before the cursor could be completed
+// to. line is the text before the cursor; start is the index in line where
+// the word being completed begins. Candidates not starting with that word
+// are ignored.
+type Completer func(line string) (start int, candidates []string)
+
+// Editor reads the lines typed by the user
+type Editor struct {
+	// Completer completes words when Tab is pressed, nil for no completion
+	Completer Completer
+	// History is recalled with the up and down keys and searched with
+	// Ctrl-R, nil for no history. Lines are not added to it by ReadLine.
+	History *History
+
+	in       *os.File
+	out      io.Writer
+	reader   *bufio.Reader
+	terminal bool
+}
+
+// New returns an editor reading from in and echoing to out
+func New(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		in:       in,
+		out:      out,
+		reader:   bufio.NewReader(in),
+		terminal: isTerminal(int(in.Fd())),
+	}
+}
+
+// IsTerminal reports whether the editor reads from a terminal, rather than
+// from a pipe or a file
+func (e *Editor) IsTerminal() bool {
+	return e.terminal
+}
+
+// ReadLine shows prompt and returns the line typed, without its newline.
+// It returns io.EOF at the end of the input or on Ctrl-D on an empty line,
+// and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	return e.read(prompt, e.Completer, e.History)
+}
+
+// Ask is like ReadLine without completion or history, for answers to questions
+func (e *Editor) Ask(prompt string) (string, error) {
+	return e.read(prompt, nil, nil)
+}
+
+// read edits a line in raw mode when the input is a terminal, and reads
+// it as is otherwise
+func (e *Editor) read(prompt string, completer Completer, history *History) (string, error) {
+	if !e.terminal {
+		return e.readPlain(prompt)
+	}
+
+	restore, err := makeRaw(int(e.in.Fd()))
+	if err != nil {
+		return e.readPlain(prompt)
+	}
+	defer restore()
+
+	s := &lineState{r: e.reader, w: e.out, prompt: prompt, completer: completer, history: history}
+	return s.edit()
+}
+
+// readPlain reads a line without any editing, e.g. from a pipe
+func (e *Editor) readPlain(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+
+	line, err := e.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Control keys read from the terminal
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Special keys, sent by the terminal as escape sequences. They are given
+// negative values so they never clash with typed runes.
+const (
+	keyUnknown rune = -(iota + 1)
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyDelete
+)
+
+// lineState is a line being edited in a terminal
+type lineState struct {
+	r         *bufio.Reader
+	w         io.Writer
+	prompt    string
+	completer Completer
+	history   *History
+
+	buf []rune
+	pos int // cursor position in buf
+	// lastTab is set when the previous key was Tab, so a second Tab
+	// lists the candidates
+	lastTab bool
+	// recalled is the index of the history entry shown, len(entries)
+	// for the line being typed, which is kept in draft meanwhile
+	recalled int
+	draft    []rune
+}
+
+// edit reads keys until the line is entered
+func (s *lineState) edit() (string, error) {
+	s.recalled = len(s.historyEntries())
+	s.refresh()
+
+	for {
+		key, err := s.readKey()
+		if err != nil {
+			return "", err
+		}
+		if key == keyCtrlR {
+			// The key ending the search is handled on the line found
+			if key, err = s.searchHistory(); err != nil {
+				return "", err
+			}
+		}
+
+		tab := false
+		switch key {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(s.w, "\r\n")
+			return string(s.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(s.w, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				fmt.Fprint(s.w, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteAtCursor()
+		case keyTab:
+			s.complete()
+			tab = true
+		case keyBackspace, keyCtrlH:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAtCursor()
+			}
+		case keyDelete:
+			s.deleteAtCursor()
+		case keyUp, keyCtrlP:
+			s.recall(s.recalled - 1)
+		case keyDown, keyCtrlN:
+			s.recall(s.recalled + 1)
+		case keyLeft, keyCtrlB:
+			s.pos = max(s.pos-1, 0)
+		case keyRight, keyCtrlF:
+			s.pos = min(s.pos+1, len(s.buf))
+		case keyHome, keyCtrlA:
+			s.pos = 0
+		case keyEnd, keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = slices.Delete(s.buf, 0, s.pos)
+			s.pos = 0
+		case keyCtrlW:
+			s.deleteWord()
+		case keyCtrlL:
+			// Clear the screen, the line is redrawn at the top
+			fmt.Fprint(s.w, "\033[H\033[2J")
+		default:
+			if key >= ' ' {
+				s.buf = slices.Insert(s.buf, s.pos, key)
+				s.pos++
+			}
+		}
+
+		s.lastTab = tab
+		s.refresh()
+	}
+}
+
+// readKey reads one key, decoding the escape sequences of special keys
+func (s *lineState) readKey() (rune, error) {
+	r, _, err := s.r.ReadRune()
+	if err != nil || r != keyEscape {
+		return r, err
+	}
+
+	next, _, err := s.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if next != '[' && next != 'O' {
+		return keyUnknown, nil
+	}
+
+	// Parameters come before the final byte, e.g. "3" in ESC [ 3 ~
+	var params []rune
+	for {
+		c, _, err := s.r.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if c >= '@' && c <= '~' {
+			return escapeKey(string(params), c), nil
+		}
+		params = append(params, c)
+	}
+}
+
+// escapeKey returns the key sent as an escape sequence with params and final
+func escapeKey(params string, final rune) rune {
+	switch final {
+	case 'A':
+		return keyUp
+	case 'B':
+		return keyDown
+	case 'C':
+		return keyRight
+	case 'D':
+		return keyLeft
+	case 'H':
+		return keyHome
+	case 'F':
+		return keyEnd
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyHome
+		case "4", "8":
+			return keyEnd
+		case "3":
+			return keyDelete
+		}
+	}
+	return keyUnknown
+}
+
+// refresh redraws the prompt and the line, and puts the cursor back
+func (s *lineState) refresh() {
+	fmt.Fprintf(s.w, "\r%s%s\033[K", s.prompt, string(s.buf))
+	if back := len(s.buf) - s.pos; back > 0 {
+		fmt.Fprintf(s.w, "\033[%dD", back)
+	}
+}
+
+// deleteAtCursor deletes the rune under the cursor
+func (s *lineState) deleteAtCursor() {
+	if s.pos < len(s.buf) {
+		s.buf = slices.Delete(s.buf, s.pos, s.pos+1)
+	}
+}
+
+// deleteWord deletes the word before the cursor, with any spaces between them
+func (s *lineState) deleteWord() {
+	start := s.pos
+	for start > 0 && unicode.IsSpace(s.buf[start-1]) {
+		start--
+	}
+	for start > 0 && !unicode.IsSpace(s.buf[start-1]) {
+		start--
+	}
+	s.buf = slices.Delete(s.buf, start, s.pos)
+	s.pos = start
+}
+
+// historyEntries returns the entries of the history, none without one
+func (s *lineState) historyEntries() []string {
+	if s.history == nil {
+		return nil
+	}
+	return s.history.Entries()
+}
+
+// recall replaces the line with history entry i, or with the line that
+// was being typed when i is past the last entry
+func (s *lineState) recall(i int) {
+	entries := s.historyEntries()
+	if i < 0 || i > len(entries) || i == s.recalled {
+		return
+	}
+
+	if s.recalled == len(entries) {
+		s.draft = slices.Clone(s.buf)
+	}
+	s.recalled = i
+	if i == len(entries) {
+		s.buf = s.draft
+	} else {
+		s.buf = []rune(entries[i])
+	}
+	s.pos = len(s.buf)
+}
+
+// searchHistory searches the history backwards for the text typed, Ctrl-R
+// going to the previous match. Ctrl-G or Ctrl-C put back the line as it
+// was. Any other key ends the search with the line found, and is returned
+// to be handled as usual.
+func (s *lineState) searchHistory() (rune, error) {
+	entries := s.historyEntries()
+	originalBuf, originalPos := slices.Clone(s.buf), s.pos
+
+	var query []rune
+	match, failed := len(entries), false
+	// search shows the last entry containing query, starting at entry from
+	search := func(from int) {
+		for i := min(from, len(entries)-1); i >= 0; i-- {
+			if strings.Contains(entries[i], string(query)) {
+				match, failed = i, false
+				s.buf = []rune(entries[i])
+				s.pos = len(s.buf)
+				return
+			}
+		}
+		failed = true
+	}
+
+	for {
+		status := ""
+		if failed {
+			status = "failing "
+		}
+		fmt.Fprintf(s.w, "\r(%sreverse-i-search)`%s': %s\033[K", status, string(query), string(s.buf))
+
+		key, err := s.readKey()
+		if err != nil {
+			return 0, err
+		}
+
+		switch key {
+		case keyCtrlR:
+			search(match - 1)
+		case keyBackspace, keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				search(len(entries) - 1)
+			}
+		case keyCtrlG, keyCtrlC:
+			s.buf, s.pos = originalBuf, originalPos
+			return keyUnknown, nil
+		default:
+			if key < ' ' {
+				s.recalled = len(entries)
+				if match < len(entries) {
+					s.recalled = match
+					s.draft = originalBuf
+				}
+				return key, nil
+			}
+			query = append(query, key)
+			search(match)
+		}
+	}
+}
+
+// complete completes the word before the cursor as far as the candidates
+// agree, or lists them when Tab is pressed twice without progress
+func (s *lineState) complete() {
+	if s.completer == nil {
+		return
+	}
+
+	before := string(s.buf[:s.pos])
+	start, all := s.completer(before)
+	start = max(0, min(start, len(before)))
+	word := before[start:]
+
+	var candidates []string
+	for _, candidate := range all {
+		if strings.HasPrefix(candidate, word) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Strings(candidates)
+	candidates = slices.Compact(candidates)
+	if len(candidates) == 0 {
+		fmt.Fprint(s.w, "\a")
+		return
+	}
+
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if len(completion) > len(word) {
+		wordStart := utf8.RuneCountInString(before[:start])
+		s.buf = slices.Concat(s.buf[:wordStart], []rune(completion), s.buf[s.pos:])
+		s.pos = wordStart + utf8.RuneCountInString(completion)
+		return
+	}
+
+	if !s.lastTab {
+		fmt.Fprint(s.w, "\a")
+		return
+	}
+	fmt.Fprintf(s.w, "\r\n%s\r\n", strings.Join(candidates, "  "))
+}
+
+// commonPrefix returns the longest prefix shared by words
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
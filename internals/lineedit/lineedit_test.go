@@ -0,0 +1,172 @@
+package lineedit
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// editKeys edits a line from the keys typed, as a terminal would send them
+func editKeys(keys string, completer Completer) (string, error) {
+	s := &lineState{
+		r:         bufio.NewReader(strings.NewReader(keys)),
+		w:         io.Discard,
+		prompt:    "> ",
+		completer: completer,
+	}
+	return s.edit()
+}
+
+func TestEdit(t *testing.T) {
+	cases := map[string]struct {
+		keys string
+		line string
+	}{
+		"typing":           {"catch pikachu\r", "catch pikachu"},
+		"backspace":        {"pikachuu\x7f\r", "pikachu"},
+		"arrows":           {"pkachu\x1b[D\x1b[D\x1b[D\x1b[D\x1b[Di\r", "pikachu"},
+		"home and end":     {"chu\x01pika\x05!\r", "pikachu!"},
+		"delete":           {"pixkachu\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[3~\r", "pikachu"},
+		"kill to end":      {"pikachu\x1b[D\x1b[D\x1b[D\x0b\r", "pika"},
+		"kill to start":    {"pika pikachu\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x15\r", "pikachu"},
+		"delete word":      {"catch pikachu  \x17raichu\r", "catch raichu"},
+		"unknown keys":     {"pika\x1b[A\x1b[15~chu\r", "pikachu"},
+		"multibyte runes":  {"flabébé\x7f\x7f\x7fe\r", "flabe"},
+		"line feed enters": {"pikachu\n", "pikachu"},
+	}
+	for name, c := range cases {
+		line, err := editKeys(c.keys, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if line != c.line {
+			t.Errorf("%s: expected %q, got %q", name, c.line, line)
+		}
+	}
+}
+
+func TestEditEnds(t *testing.T) {
+	if _, err := editKeys("\x04", nil); err != io.EOF {
+		t.Errorf("expected io.EOF on Ctrl-D, got %v", err)
+	}
+	if _, err := editKeys("pika\x03", nil); err != ErrInterrupted {
+		t.Errorf("expected ErrInterrupted on Ctrl-C, got %v", err)
+	}
+	if _, err := editKeys("pika", nil); err != io.EOF {
+		t.Errorf("expected io.EOF at the end of the input, got %v", err)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	completer := func(line string) (int, []string) {
+		start := strings.LastIndex(line, " ") + 1
+		if start == 0 {
+			return 0, []string{"catch", "catchmode", "explore", "exit"}
+		}
+		return start, []string{"pikachu", "pidgey", "pidgeotto"}
+	}
+
+	cases := map[string]string{
+		"ex\t\t\r":            "ex",
+		"expl\t\r":            "explore ",
+		"catch pidgeo\t\r":    "catch pidgeotto ",
+		"catch pidg\ty\r":     "catch pidgey",
+		"catch zubat\t\r":     "catch zubat",
+		"catch\t\t pikachu\r": "catch pikachu",
+		"catch  pik\t chu\r":  "catch  pikachu  chu",
+		"explore pidg\tx\x7f": "",
+	}
+	for keys, expected := range cases {
+		line, _ := editKeys(keys, completer)
+		if line != expected {
+			t.Errorf("%q: expected %q, got %q", keys, expected, line)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(3)
+	for _, line := range []string{"map", "explore 1", "", "explore 1", "map", "party"} {
+		history.Add(line)
+	}
+
+	// Repeated and blank lines are dropped, and only the last 3 lines kept
+	expected := []string{"explore 1", "map", "party"}
+	if !slices.Equal(history.Entries(), expected) {
+		t.Fatalf("expected %v, got %v", expected, history.Entries())
+	}
+
+	var saved strings.Builder
+	if err := history.Save(&saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewHistory(10)
+	if err := loaded.Load(strings.NewReader(saved.String())); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.Entries(), expected) {
+		t.Errorf("expected %v to be loaded, got %v", expected, loaded.Entries())
+	}
+}
+
+func TestEditWithHistory(t *testing.T) {
+	history := NewHistory(10)
+	for _, line := range []string{"map", "explore viridian-forest-area", "catch pikachu", "party"} {
+		history.Add(line)
+	}
+
+	cases := map[string]string{
+		"\x1b[A\r":                               "party",
+		"\x1b[A\x1b[A\x1b[A\r":                   "explore viridian-forest-area",
+		"\x1b[A\x1b[A\x1b[B\r":                   "party",
+		"par\x1b[A\x1b[B\r":                      "par",
+		"\x1b[A\x1b[A\x1b[A\x1b[A\x1b[A\x1b[A\r": "map",
+		"\x10\x10 1\r":                           "catch pikachu 1",
+		"\x12cat\r":                              "catch pikachu",
+		"\x12a\x12\r":                            "catch pikachu",
+		"\x12a\x12\x12\r":                        "explore viridian-forest-area",
+		"\x12pika\x1b[D\x1b[D\x7f\r":             "catch pikahu",
+		"bag\x12zubat\x07\r":                     "bag",
+		"\x12cat\x1b[A\r":                        "explore viridian-forest-area",
+	}
+	for keys, expected := range cases {
+		s := &lineState{
+			r:       bufio.NewReader(strings.NewReader(keys)),
+			w:       io.Discard,
+			history: history,
+		}
+		line, err := s.edit()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", keys, err)
+			continue
+		}
+		if line != expected {
+			t.Errorf("%q: expected %q, got %q", keys, expected, line)
+		}
+	}
+}
+
+func TestReadPlain(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	io.WriteString(w, "catch pikachu\n")
+	w.Close()
+
+	e := New(r, io.Discard)
+	if e.IsTerminal() {
+		t.Fatal("expected a pipe not to be a terminal")
+	}
+	if line, err := e.ReadLine("> "); err != nil || line != "catch pikachu" {
+		t.Errorf("expected %q, got %q (%v)", "catch pikachu", line, err)
+	}
+	if _, err := e.ReadLine("> "); err != io.EOF {
+		t.Errorf("expected io.EOF at the end of the input, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+
+package lineedit
+
+import "errors"
+
+// isTerminal reports whether fd is a terminal. Raw mode is only supported
+// on Linux and macOS, so input is always read as plain lines elsewhere.
+func isTerminal(fd int) bool {
+	return false
+}
+
+// makeRaw is not supported on this platform
+func makeRaw(fd int) (restore func(), err error) {
+	return nil, errors.New("raw terminal mode is not supported")
+}
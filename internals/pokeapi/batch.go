@@ -0,0 +1,96 @@
+package pokeapi
+
+import "sync"
+
+// defaultWorkers is how many requests a batch makes at once when not told otherwise
+const defaultWorkers = 8
+
+// BatchOptions configures a batch fetch
+type BatchOptions struct {
+	// Workers is how many resources are fetched at once, 0 for the default
+	Workers int
+	// OnProgress, when set, is called after each resource with the number
+	// fetched so far. Calls are serialized.
+	OnProgress func(done, total int)
+}
+
+// BatchResult is the outcome of fetching one resource of a batch
+type BatchResult[T any] struct {
+	Value *T
+	Err   error
+}
+
+// Batch fetches every name concurrently with a bounded pool of workers.
+// Results are in the order of names, each with its own error, so one
+// failure doesn't lose the rest. fetch goes through the client as usual, so
+// the cache and the rate limiter apply.
+func Batch[T any](names []string, fetch func(name string) (*T, error), opts BatchOptions) []BatchResult[T] {
+	results := make([]BatchResult[T], len(names))
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	workers = min(workers, len(names))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				value, err := fetch(names[i])
+				results[i] = BatchResult[T]{Value: value, Err: err}
+
+				if opts.OnProgress != nil {
+					mu.Lock()
+					done++
+					opts.OnProgress(done, len(names))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// GetPokemonBatch fetches many Pokemon at once. See Batch.
+func (c *Client) GetPokemonBatch(names []string, opts BatchOptions) []BatchResult[Pokemon] {
+	return Batch(names, c.Catch, opts)
+}
+
+// GetMoveBatch fetches many moves at once. See Batch.
+func (c *Client) GetMoveBatch(names []string, opts BatchOptions) []BatchResult[Move] {
+	return Batch(names, c.GetMove, opts)
+}
+
+// GetTypeBatch fetches many types at once. See Batch.
+func (c *Client) GetTypeBatch(names []string, opts BatchOptions) []BatchResult[Type] {
+	return Batch(names, c.GetType, opts)
+}
+
+// GetItemBatch fetches many items at once. See Batch.
+func (c *Client) GetItemBatch(names []string, opts BatchOptions) []BatchResult[Item] {
+	return Batch(names, c.GetItem, opts)
+}
+
+// GetPokedexBatch fetches many Pokedexes at once. See Batch.
+func (c *Client) GetPokedexBatch(names []string, opts BatchOptions) []BatchResult[Pokedex] {
+	return Batch(names, c.GetPokedex, opts)
+}
+
+// GetRegionBatch fetches many regions at once. See Batch.
+func (c *Client) GetRegionBatch(names []string, opts BatchOptions) []BatchResult[Region] {
+	return Batch(names, c.GetRegion, opts)
+}
@@ -0,0 +1,96 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	var running, peak atomic.Int32
+	fetch := func(name string) (*string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if name == "missingno" {
+			return nil, fmt.Errorf("no such Pokemon")
+		}
+		upper := strings.ToUpper(name)
+		return &upper, nil
+	}
+
+	names := []string{"bulbasaur", "missingno", "charmander", "squirtle", "pikachu"}
+	calls := 0
+	results := Batch(names, fetch, BatchOptions{
+		Workers:    2,
+		OnProgress: func(done, total int) { calls++ },
+	})
+
+	if len(results) != len(names) {
+		t.Fatalf("expected %d results, got %d", len(names), len(results))
+	}
+	for i, name := range names {
+		if name == "missingno" {
+			if results[i].Err == nil {
+				t.Errorf("expected an error for %s", name)
+			}
+			continue
+		}
+		if results[i].Err != nil || *results[i].Value != strings.ToUpper(name) {
+			t.Errorf("result %d: expected %s, got %v (%v)", i, strings.ToUpper(name), results[i].Value, results[i].Err)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 fetches at once, got %d", peak.Load())
+	}
+	if calls != len(names) {
+		t.Errorf("expected %d progress calls, got %d", len(names), calls)
+	}
+}
+
+func TestGetPokemonBatchUsesCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprintf(w, `{"name": %q}`, strings.TrimPrefix(r.URL.Path, "/pokemon/"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient()
+	client.BaseURL = server.URL
+
+	names := []string{"pidgey", "rattata", "pidgey"}
+	client.GetPokemonBatch(names, BatchOptions{Workers: 1})
+	results := client.GetPokemonBatch(names, BatchOptions{})
+
+	if results[1].Value.Name != "rattata" {
+		t.Errorf("expected rattata, got %s", results[1].Value.Name)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests thanks to the cache, got %d", requests.Load())
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(50)
+
+	// The burst is free, then requests are spaced 20ms apart
+	start := time.Now()
+	for range 55 {
+		limiter.Wait()
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected 5 requests past the burst to take at least 80ms, took %v", elapsed)
+	}
+}
@@ -1,9 +1,7 @@
 package pokeapi
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -15,205 +13,168 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Cache      *pokecache.Cache
-}
-
-// Config
-type Config struct {
-	Next          *string
-	Previous      *string
-	CaughtPokemon *map[string]Pokemon
+	// Limiter spaces out requests to PokeAPI, nil for no limit
+	Limiter *RateLimiter
 }
 
 // NewClient create a new PokeAPI client
 func NewClient() *Client {
 	return &Client{
-		BaseURL:    "https://pokeapi.co/api/v2",
+		BaseURL:    DefaultBaseURL,
 		HTTPClient: &http.Client{},
 		Cache:      pokecache.NewCache(5 * time.Minute),
+		Limiter:    NewRateLimiter(defaultRequestsPerSecond),
 	}
 }
 
 // =====================================================
 // =====================================================
 
-// ListLocationAreas retrieves the list of location areas
-func (c *Client) ListLocationAreas(config *Config) (*LocationAreaResp, error) {
-	url := fmt.Sprintf("%s/location-area", c.BaseURL)
-
-	if config.Next != nil {
-		url = *config.Next
-	}
+// ListLocationAreas retrieves the page of at most limit location areas
+// starting at offset. It doesn't keep any paging state: callers pass the
+// offset of the page they want.
+func (c *Client) ListLocationAreas(offset, limit int) (*Page[LocationAreaResult], error) {
+	return listPage[LocationAreaResult](c, "location-area", offset, limit)
+}
 
-	// Check if we have this URL cached
-	if cachedData, found := c.Cache.Get(url); found {
-		// Use cached data
-		var locationAreaResp LocationAreaResp
-		err := json.Unmarshal(cachedData, &locationAreaResp)
-		if err != nil {
-			// Update config with pagination links
-			config.Next = locationAreaResp.Next
-			config.Previous = locationAreaResp.Previous
-			return &locationAreaResp, nil
-		}
-	}
+// Explore retrieves a location area and the Pokemon that can be encountered in it
+func (c *Client) Explore(locationName string) (*ExploreAreaEncounter, error) {
+	url := fmt.Sprintf("%s/location-area/%s", c.BaseURL, locationName)
 
-	// Make HTTP request
-	res, err := c.HTTPClient.Get(url)
-	if err != nil {
+	var exploreEncounter ExploreAreaEncounter
+	if err := c.get(url, &exploreEncounter); err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
+	return &exploreEncounter, nil
+}
 
-	// Add to cache
-	c.Cache.Add(url, body)
+// Catch retrieves a Pokemon by name
+func (c *Client) Catch(pokemonName string) (*Pokemon, error) {
+	url := fmt.Sprintf("%s/pokemon/%s", c.BaseURL, pokemonName)
 
-	// Parse JSON
-	var locationArea LocationAreaResp
-	err = json.Unmarshal(body, &locationArea)
-	if err != nil {
+	var pokemon Pokemon
+	if err := c.get(url, &pokemon); err != nil {
 		return nil, err
 	}
 
-	// Update config
-	config.Next = locationArea.Next
-	config.Previous = locationArea.Previous
-
-	return &locationArea, nil
+	return &pokemon, nil
 }
 
-// ListPreviousLocationAreas retrieves the previous list of location areas
-func (c *Client) ListPreviousLocationAreas(config *Config) (*LocationAreaResp, error) {
-	url := fmt.Sprintf("%s/location-area", c.BaseURL)
+// GetPokemonSpecies retrieves species data (capture rate, growth rate, evolution chain...)
+func (c *Client) GetPokemonSpecies(speciesName string) (*PokemonSpecies, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%s", c.BaseURL, speciesName)
 
-	if config.Previous != nil {
-		url = *config.Previous
+	var species PokemonSpecies
+	if err := c.get(url, &species); err != nil {
+		return nil, err
 	}
 
-	// Check if we have this URL in cached
-	if cachedData, found := c.Cache.Get(url); found {
-		// Use cached data
-		var locationAreaResp LocationAreaResp
-		err := json.Unmarshal(cachedData, &locationAreaResp)
-		if err != nil {
-			return nil, err
-		}
-		return &locationAreaResp, nil
-	}
+	return &species, nil
+}
 
-	// Make HTTP request
-	res, err := c.HTTPClient.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+// GetItem retrieves an item (Poke Balls, potions, berries...) by name
+func (c *Client) GetItem(itemName string) (*Item, error) {
+	url := fmt.Sprintf("%s/item/%s", c.BaseURL, itemName)
 
-	// Read response body
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
+	var item Item
+	if err := c.get(url, &item); err != nil {
 		return nil, err
 	}
 
-	// Add to cache
-	c.Cache.Add(url, body)
+	return &item, nil
+}
+
+// GetMove retrieves a move (power, accuracy, PP...) by name
+func (c *Client) GetMove(moveName string) (*Move, error) {
+	url := fmt.Sprintf("%s/move/%s", c.BaseURL, moveName)
 
-	// Parse JSON
-	var locationArea LocationAreaResp
-	err = json.Unmarshal(body, &locationArea)
-	if err != nil {
+	var move Move
+	if err := c.get(url, &move); err != nil {
 		return nil, err
 	}
 
-	// Update config
-	config.Next = locationArea.Next
-	config.Previous = locationArea.Previous
-
-	return &locationArea, nil
+	return &move, nil
 }
 
-func (c *Client) Explore(locationName string) (*ExploreAreaEncounter, error) {
-	url := fmt.Sprintf("%s/location-area/%s", c.BaseURL, locationName)
-
-	// Check if url already exists in cache
-	if cachedData, found := c.Cache.Get(url); found {
-		// Use cached data
-		var exploreEncouter ExploreAreaEncounter
-		err := json.Unmarshal(cachedData, &exploreEncouter)
-		if err != nil {
-			return nil, err
-		}
+// GetType retrieves a type and its damage relations by name
+func (c *Client) GetType(typeName string) (*Type, error) {
+	url := fmt.Sprintf("%s/type/%s", c.BaseURL, typeName)
 
-		return &exploreEncouter, nil
-	}
-
-	// Make a request
-	res, err := http.Get(url)
-	if err != nil {
+	var t Type
+	if err := c.get(url, &t); err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	// Read a byte
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
+	return &t, nil
+}
+
+// GetNature retrieves a nature by name or ID
+func (c *Client) GetNature(nature string) (*Nature, error) {
+	url := fmt.Sprintf("%s/nature/%s", c.BaseURL, nature)
+
+	var n Nature
+	if err := c.get(url, &n); err != nil {
 		return nil, err
 	}
 
-	// Add to cache
-	c.Cache.Add(url, body)
+	return &n, nil
+}
+
+// GetGrowthRate retrieves the experience needed for each level of a growth rate
+func (c *Client) GetGrowthRate(growthRateName string) (*GrowthRate, error) {
+	url := fmt.Sprintf("%s/growth-rate/%s", c.BaseURL, growthRateName)
 
-	// Parse to JSON
-	var exploreEncouter ExploreAreaEncounter
-	err = json.Unmarshal(body, &exploreEncouter)
-	if err != nil {
+	var growthRate GrowthRate
+	if err := c.get(url, &growthRate); err != nil {
 		return nil, err
 	}
 
-	return &exploreEncouter, nil
+	return &growthRate, nil
 }
 
-func (c *Client) Catch(pokemonName string) (*Pokemon, error) {
-	url := fmt.Sprintf("%s/pokemon/%s", c.BaseURL, pokemonName)
+// GetEvolutionChain retrieves the evolution chain at url, as linked from a pokemon-species
+func (c *Client) GetEvolutionChain(url string) (*EvolutionChain, error) {
+	var chain EvolutionChain
+	if err := c.get(c.resolveURL(url), &chain); err != nil {
+		return nil, err
+	}
 
-	// Check if url already exists in cache
-	if cachedData, found := c.Cache.Get(url); found {
-		// Use cached data
-		var pokemon Pokemon
-		err := json.Unmarshal(cachedData, &pokemon)
-		if err != nil {
-			return nil, err
-		}
+	return &chain, nil
+}
 
-		return &pokemon, nil
-	}
+// GetPokedex retrieves a Pokedex (e.g. national, kanto) and its entries
+func (c *Client) GetPokedex(name string) (*Pokedex, error) {
+	url := fmt.Sprintf("%s/pokedex/%s", c.BaseURL, name)
 
-	// Make a request
-	res, err := http.Get(url)
-	if err != nil {
+	var pokedex Pokedex
+	if err := c.get(url, &pokedex); err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	// Read a byte
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
+	return &pokedex, nil
+}
+
+// GetRegion retrieves a region with its locations, Pokedexes and version groups
+func (c *Client) GetRegion(name string) (*Region, error) {
+	url := fmt.Sprintf("%s/region/%s", c.BaseURL, name)
+
+	var region Region
+	if err := c.get(url, &region); err != nil {
 		return nil, err
 	}
 
-	// Add to cache
-	c.Cache.Add(url, body)
+	return &region, nil
+}
 
-	// Parse to JSON
-	var pokemon Pokemon
-	err = json.Unmarshal(body, &pokemon)
-	if err != nil {
+// GetLocation retrieves a location and the location areas it is made of
+func (c *Client) GetLocation(name string) (*Location, error) {
+	url := fmt.Sprintf("%s/location/%s", c.BaseURL, name)
+
+	var location Location
+	if err := c.get(url, &location); err != nil {
 		return nil, err
 	}
 
-	return &pokemon, nil
+	return &location, nil
 }
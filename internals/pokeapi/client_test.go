@@ -1,26 +1,88 @@
 package pokeapi
 
-import "testing"
-
-// MockLocationAreaResponse creates a mock response for testing
-// func createMockLocationAreaResponse(next, prev string) LocationAreaResp {
-// 	return LocationAreaResp{
-// 		Count:    1118,
-// 		Next:     &next,
-// 		Previous: &prev,
-// 		Results: []LocationAreaResult{
-// 			{
-// 				Name: "canalave-city-area",
-// 				URL:  "https://pokeapi.co/api/v2/location-area/1/",
-// 			},
-// 			{
-// 				Name: "eterna-city-area",
-// 				URL:  "https://pokeapi.co/api/v2/location-area/2/",
-// 			},
-// 		},
-// 	}
-// }
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newListServer serves count resources named area-0, area-1... from any
+// list endpoint, honouring offset and limit. requests counts the calls made.
+func newListServer(t *testing.T, count int) (client *Client, requests *int) {
+	t.Helper()
+
+	requests = new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var results []string
+		for i := offset; i < min(offset+limit, count); i++ {
+			results = append(results, fmt.Sprintf(`{"name": "area-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"count": %d, "results": [%s]}`, count, strings.Join(results, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	client = NewClient()
+	client.BaseURL = server.URL
+	return client, requests
+}
 
 func TestListLocationAreas(t *testing.T) {
+	client, _ := newListServer(t, 45)
+
+	first, err := client.ListLocationAreas(0, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Number() != 1 || first.TotalPages() != 3 {
+		t.Errorf("expected page 1 of 3, got page %d of %d", first.Number(), first.TotalPages())
+	}
+	if first.HasPrevious() || !first.HasNext() {
+		t.Error("expected the first page to have a next page only")
+	}
+	if len(first.Results) != 20 || first.Results[0].Name != "area-0" {
+		t.Errorf("unexpected first page results: %v", first.Results)
+	}
+
+	last, err := client.ListLocationAreas(40, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last.Number() != 3 || !last.HasPrevious() || last.HasNext() {
+		t.Errorf("expected the last page 3 to have a previous page only, got page %d", last.Number())
+	}
+	if len(last.Results) != 5 || last.Results[4].Name != "area-44" {
+		t.Errorf("unexpected last page results: %v", last.Results)
+	}
+
+	// Cached pages come back unchanged
+	again, err := client.ListLocationAreas(0, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Count != 45 || len(again.Results) != 20 {
+		t.Errorf("expected the cached page to be decoded, got %d results of %d", len(again.Results), again.Count)
+	}
+
+	if _, err := client.ListLocationAreas(-20, 20); err == nil {
+		t.Error("expected an error for a negative offset")
+	}
+}
+
+func TestListLocationAreasExactPages(t *testing.T) {
+	client, _ := newListServer(t, 40)
 
+	page, err := client.ListLocationAreas(20, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.TotalPages() != 2 || page.HasNext() {
+		t.Errorf("expected page 2 to be the last of 2, got %d pages", page.TotalPages())
+	}
 }
@@ -0,0 +1,91 @@
+package pokeapi
+
+// Config
+type Config struct {
+	// CaughtPokemon holds every caught Pokemon keyed by its unique ID
+	CaughtPokemon map[int]*OwnedPokemon
+	// NextPokemonID is the ID given to the next caught Pokemon
+	NextPokemonID int
+	// Party holds the IDs of the (up to six) Pokemon carried along, in battle order
+	Party []int
+	// Boxes holds the IDs of the Pokemon stored in each PC box
+	Boxes [][]int
+	// Seen maps the national dex number of every species met to its name
+	Seen map[int]string
+	// Caught holds the national dex numbers of every species ever caught
+	Caught map[int]bool
+	// CatchMode selects the catch formula: "classic" (default) or "authentic"
+	CatchMode string
+	// Inventory maps an item name (e.g. "great-ball") to the quantity held
+	Inventory map[string]int
+	// Money is the PokeDollar balance
+	Money int
+	// Location is the location-area the player is currently in
+	Location string
+	// FreeCatch lets any Pokemon be caught from anywhere (sandbox mode)
+	FreeCatch bool
+	// Version is the game version (e.g. "red") used for wild encounters
+	Version string
+	// Generation selects the types and abilities in use, 0 for the latest
+	Generation int
+}
+
+// OwnedPokemon is one caught Pokemon. Two Pokemon of the same species are
+// distinct instances with their own level, IVs, EVs, nature and stats.
+type OwnedPokemon struct {
+	ID         int    `json:"id"`
+	Nickname   string `json:"nickname,omitempty"`
+	Level      int    `json:"level"`
+	Experience int    `json:"experience"`
+	Nature     string `json:"nature"`
+	// Gender is "male", "female" or "genderless"
+	Gender    string `json:"gender"`
+	Shiny     bool   `json:"shiny"`
+	Happiness int    `json:"happiness"`
+	HeldItem  string `json:"held_item,omitempty"`
+	// IVs, EVs and Stats are keyed by stat name ("hp", "attack", "special-defense"...)
+	IVs   map[string]int `json:"ivs"`
+	EVs   map[string]int `json:"evs"`
+	Stats map[string]int `json:"stats"`
+	// Damage is the HP lost in battle and not healed yet, 0 at full health
+	Damage int `json:"damage,omitempty"`
+	// Moves are the names of the (up to four) moves the Pokemon knows
+	Moves   []string       `json:"moves"`
+	Pokemon PokemonSummary `json:"pokemon"`
+}
+
+// PokemonSummary is the part of the species data kept with a caught Pokemon.
+// The rest, e.g. its learnset or abilities, is fetched when needed. Its
+// fields keep the JSON names of Pokemon, so saves holding the full Pokemon
+// still load.
+type PokemonSummary struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	Species        NamedAPIResource `json:"species"`
+	BaseExperience int              `json:"base_experience"`
+	Stats          []PokemonStat    `json:"stats"`
+	Types          []PokemonType    `json:"types"`
+	// PastTypes are the types of older generations, see Pokemon
+	PastTypes []PokemonPastType `json:"past_types,omitempty"`
+}
+
+// Summary returns the part of p kept with a caught Pokemon
+func (p *Pokemon) Summary() PokemonSummary {
+	return PokemonSummary{
+		ID:             p.ID,
+		Name:           p.Name,
+		Species:        p.Species,
+		BaseExperience: p.BaseExperience,
+		Stats:          p.Stats,
+		Types:          p.Types,
+		PastTypes:      p.PastTypes,
+	}
+}
+
+// Name returns the nickname of the Pokemon, or its species name when it has none
+func (p *OwnedPokemon) Name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Pokemon.Name
+}
@@ -0,0 +1,117 @@
+package pokeapi
+
+import (
+	"fmt"
+	"iter"
+)
+
+// defaultPageSize is how many resources Resources fetches per request
+const defaultPageSize = 100
+
+// ResourceList is the count/next/previous/results envelope shared by every
+// list endpoint (/pokemon, /move, /item, /location-area...)
+type ResourceList[T any] struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []T     `json:"results"`
+}
+
+// Page is one page of a list endpoint, addressed by offset and limit
+type Page[T any] struct {
+	Offset  int
+	Limit   int
+	Count   int // total number of results across all pages
+	Results []T
+}
+
+// Number returns the 1-based number of the page
+func (p *Page[T]) Number() int {
+	return p.Offset/p.Limit + 1
+}
+
+// TotalPages returns how many pages of Limit results there are, at least one
+func (p *Page[T]) TotalPages() int {
+	return max((p.Count+p.Limit-1)/p.Limit, 1)
+}
+
+// HasPrevious reports whether a page comes before this one
+func (p *Page[T]) HasPrevious() bool {
+	return p.Offset > 0
+}
+
+// HasNext reports whether a page comes after this one
+func (p *Page[T]) HasNext() bool {
+	return p.Offset+p.Limit < p.Count
+}
+
+// listPage retrieves the page of at most limit results of endpoint starting at offset
+func listPage[T any](c *Client, endpoint string, offset, limit int) (*Page[T], error) {
+	if offset < 0 || limit <= 0 {
+		return nil, fmt.Errorf("invalid page: offset %d, limit %d", offset, limit)
+	}
+
+	url := fmt.Sprintf("%s/%s?offset=%d&limit=%d", c.BaseURL, endpoint, offset, limit)
+
+	var list ResourceList[T]
+	if err := c.get(url, &list); err != nil {
+		return nil, err
+	}
+
+	return &Page[T]{
+		Offset:  offset,
+		Limit:   limit,
+		Count:   list.Count,
+		Results: list.Results,
+	}, nil
+}
+
+// ListOptions selects the part of a list endpoint to walk
+type ListOptions struct {
+	// Offset is the index of the first resource
+	Offset int
+	// Limit is the most resources to return, 0 for all of them
+	Limit int
+	// PageSize is how many resources are fetched per request, 0 for the default
+	PageSize int
+}
+
+// Resources returns an iterator over the resources of a list endpoint
+// (e.g. "pokemon", "move", "item"), fetching pages as the loop goes.
+// Breaking out of the loop stops fetching. On failure the error is yielded
+// once and the iteration ends.
+func (c *Client) Resources(endpoint string, opts ListOptions) iter.Seq2[NamedAPIResource, error] {
+	return func(yield func(NamedAPIResource, error) bool) {
+		pageSize := opts.PageSize
+		if pageSize <= 0 {
+			pageSize = defaultPageSize
+		}
+		if opts.Limit > 0 {
+			pageSize = min(pageSize, opts.Limit)
+		}
+
+		offset, yielded := opts.Offset, 0
+		for {
+			page, err := listPage[NamedAPIResource](c, endpoint, offset, pageSize)
+			if err != nil {
+				yield(NamedAPIResource{}, err)
+				return
+			}
+
+			for _, resource := range page.Results {
+				if opts.Limit > 0 && yielded == opts.Limit {
+					return
+				}
+				if !yield(resource, nil) {
+					return
+				}
+				yielded++
+			}
+
+			if !page.HasNext() || len(page.Results) == 0 || (opts.Limit > 0 && yielded == opts.Limit) {
+				return
+			}
+			offset += pageSize
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package pokeapi
+
+import "testing"
+
+func TestResources(t *testing.T) {
+	client, requests := newListServer(t, 45)
+
+	var names []string
+	for resource, err := range client.Resources("pokemon", ListOptions{PageSize: 20}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, resource.Name)
+	}
+	if len(names) != 45 || names[0] != "area-0" || names[44] != "area-44" {
+		t.Errorf("expected the 45 resources in order, got %d", len(names))
+	}
+	if *requests != 3 {
+		t.Errorf("expected 3 pages to be fetched, got %d", *requests)
+	}
+}
+
+func TestResourcesEarlyBreak(t *testing.T) {
+	client, requests := newListServer(t, 45)
+
+	count := 0
+	for _, err := range client.Resources("pokemon", ListOptions{PageSize: 20}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if *requests != 1 {
+		t.Errorf("expected breaking out to stop fetching, got %d requests", *requests)
+	}
+}
+
+func TestResourcesOffsetLimit(t *testing.T) {
+	client, requests := newListServer(t, 45)
+
+	var names []string
+	for resource, err := range client.Resources("pokemon", ListOptions{Offset: 10, Limit: 20, PageSize: 10}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, resource.Name)
+	}
+	if len(names) != 20 || names[0] != "area-10" || names[19] != "area-29" {
+		t.Errorf("expected area-10 to area-29, got %v", names)
+	}
+	if *requests != 2 {
+		t.Errorf("expected 2 pages to be fetched, got %d", *requests)
+	}
+}
+
+func TestResourcesError(t *testing.T) {
+	client := NewClient()
+	client.BaseURL = "http://127.0.0.1:0"
+
+	errs := 0
+	for _, err := range client.Resources("pokemon", ListOptions{}) {
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected the error to be yielded once, got %d", errs)
+	}
+}
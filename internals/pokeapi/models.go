@@ -1,12 +1,9 @@
 package pokeapi
 
-// LocationAreaResp represents the response from the location-area endpoint
-type LocationAreaResp struct {
-	Count    int                  `json:"count"`
-	Next     *string              `json:"next"`
-	Previous *string              `json:"previous"`
-	Results  []LocationAreaResult `json:"results"`
-}
+import (
+	"strconv"
+	"strings"
+)
 
 // LocationAreaResult represents a single location area in the response
 type LocationAreaResult struct {
@@ -16,23 +13,11 @@ type LocationAreaResult struct {
 
 // ExploreAreaEncounter represent list of monsters that can be encountered in specific location
 type ExploreAreaEncounter struct {
-	ID                   int    `json:"id"`
-	Name                 string `json:"name"`
-	GameIndex            int    `json:"game_index"`
-	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"encounter_method_rates"`
-	Location struct {
+	ID                   int                   `json:"id"`
+	Name                 string                `json:"name"`
+	GameIndex            int                   `json:"game_index"`
+	EncounterMethodRates []EncounterMethodRate `json:"encounter_method_rates"`
+	Location             struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"location"`
@@ -43,48 +28,59 @@ type ExploreAreaEncounter struct {
 			URL  string `json:"url"`
 		} `json:"language"`
 	} `json:"names"`
-	PokemonEncounters []struct {
-		Pokemon struct {
+	PokemonEncounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+// EncounterMethodRate is the chance of an encounter per step for a method (walk, surf, old-rod...)
+type EncounterMethodRate struct {
+	EncounterMethod struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"encounter_method"`
+	VersionDetails []struct {
+		Rate    int `json:"rate"`
+		Version struct {
 			Name string `json:"name"`
 			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-			MaxChance        int `json:"max_chance"`
-			EncounterDetails []struct {
-				MinLevel        int           `json:"min_level"`
-				MaxLevel        int           `json:"max_level"`
-				ConditionValues []interface{} `json:"condition_values"`
-				Chance          int           `json:"chance"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-			} `json:"encounter_details"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
+		} `json:"version"`
+	} `json:"version_details"`
 }
 
-type Pokemon struct {
-	ID             int    `json:"id"`
-	Name           string `json:"name"`
-	BaseExperience int    `json:"base_experience"`
-	Height         int    `json:"height"`
-	IsDefault      bool   `json:"is_default"`
-	Order          int    `json:"order"`
-	Weight         int    `json:"weight"`
-	Abilities      []struct {
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
-		Ability  struct {
+// PokemonEncounter lists how a Pokemon can be encountered in an area, per game version
+type PokemonEncounter struct {
+	Pokemon        NamedAPIResource `json:"pokemon"`
+	VersionDetails []struct {
+		Version struct {
 			Name string `json:"name"`
 			URL  string `json:"url"`
-		} `json:"ability"`
-	} `json:"abilities"`
-	Forms []struct {
+		} `json:"version"`
+		MaxChance        int               `json:"max_chance"`
+		EncounterDetails []EncounterDetail `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+// EncounterDetail is one way of meeting a Pokemon: its method, chance and level range
+type EncounterDetail struct {
+	MinLevel        int           `json:"min_level"`
+	MaxLevel        int           `json:"max_level"`
+	ConditionValues []interface{} `json:"condition_values"`
+	Chance          int           `json:"chance"`
+	Method          struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"method"`
+}
+
+type Pokemon struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	BaseExperience int              `json:"base_experience"`
+	Height         int              `json:"height"`
+	IsDefault      bool             `json:"is_default"`
+	Order          int              `json:"order"`
+	Weight         int              `json:"weight"`
+	Abilities      []PokemonAbility `json:"abilities"`
+	Forms          []struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"forms"`
@@ -127,10 +123,7 @@ type Pokemon struct {
 			Order int `json:"order"`
 		} `json:"version_group_details"`
 	} `json:"moves"`
-	Species struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
+	Species NamedAPIResource `json:"species"`
 	Sprites struct {
 		BackDefault      string      `json:"back_default"`
 		BackFemale       interface{} `json:"back_female"`
@@ -311,43 +304,242 @@ type Pokemon struct {
 		Latest string `json:"latest"`
 		Legacy string `json:"legacy"`
 	} `json:"cries"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	PastTypes []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Types []struct {
-			Slot int `json:"slot"`
-			Type struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"type"`
-		} `json:"types"`
-	} `json:"past_types"`
-	PastAbilities []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Abilities []struct {
-			Ability  interface{} `json:"ability"`
-			IsHidden bool        `json:"is_hidden"`
-			Slot     int         `json:"slot"`
-		} `json:"abilities"`
-	} `json:"past_abilities"`
+	Stats         []PokemonStat        `json:"stats"`
+	Types         []PokemonType        `json:"types"`
+	PastTypes     []PokemonPastType    `json:"past_types"`
+	PastAbilities []PokemonPastAbility `json:"past_abilities"`
+}
+
+// PokemonStat is a base stat of a Pokemon and the effort values it gives when defeated
+type PokemonStat struct {
+	BaseStat int              `json:"base_stat"`
+	Effort   int              `json:"effort"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+// PokemonType is one of the (up to two) types of a Pokemon
+type PokemonType struct {
+	Slot int              `json:"slot"`
+	Type NamedAPIResource `json:"type"`
+}
+
+// PokemonPastType lists the types a Pokemon had up to and including Generation
+type PokemonPastType struct {
+	Generation NamedAPIResource `json:"generation"`
+	Types      []PokemonType    `json:"types"`
+}
+
+// PokemonAbility is one of the ability slots of a Pokemon. Ability is nil
+// in past abilities when the slot did not exist in that generation.
+type PokemonAbility struct {
+	IsHidden bool              `json:"is_hidden"`
+	Slot     int               `json:"slot"`
+	Ability  *NamedAPIResource `json:"ability"`
+}
+
+// PokemonPastAbility lists the ability slots that differed up to and including Generation
+type PokemonPastAbility struct {
+	Generation NamedAPIResource `json:"generation"`
+	Abilities  []PokemonAbility `json:"abilities"`
+}
+
+// NamedAPIResource is the {name, url} reference PokeAPI uses to link resources
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ID returns the numeric ID at the end of the resource URL, 0 when there is none
+func (r NamedAPIResource) ID() int {
+	path := strings.TrimSuffix(r.URL, "/")
+	id, err := strconv.Atoi(path[strings.LastIndex(path, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// PokemonSpecies represents the response from the pokemon-species endpoint
+type PokemonSpecies struct {
+	ID                 int               `json:"id"`
+	Name               string            `json:"name"`
+	Order              int               `json:"order"`
+	GenderRate         int               `json:"gender_rate"`
+	CaptureRate        int               `json:"capture_rate"`
+	BaseHappiness      int               `json:"base_happiness"`
+	IsBaby             bool              `json:"is_baby"`
+	IsLegendary        bool              `json:"is_legendary"`
+	IsMythical         bool              `json:"is_mythical"`
+	HatchCounter       int               `json:"hatch_counter"`
+	GrowthRate         NamedAPIResource  `json:"growth_rate"`
+	Generation         NamedAPIResource  `json:"generation"`
+	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	PokedexNumbers []struct {
+		EntryNumber int              `json:"entry_number"`
+		Pokedex     NamedAPIResource `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Varieties []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// Item represents the response from the item endpoint
+type Item struct {
+	ID            int                `json:"id"`
+	Name          string             `json:"name"`
+	Cost          int                `json:"cost"`
+	FlingPower    int                `json:"fling_power"`
+	Category      NamedAPIResource   `json:"category"`
+	Attributes    []NamedAPIResource `json:"attributes"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+// ShortEffect returns the English short effect description of the item
+func (i *Item) ShortEffect() string {
+	for _, entry := range i.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
+
+// Move represents the response from the move endpoint
+type Move struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Accuracy    *int             `json:"accuracy"`
+	Power       *int             `json:"power"`
+	PP          int              `json:"pp"`
+	Priority    int              `json:"priority"`
+	Type        NamedAPIResource `json:"type"`
+	DamageClass NamedAPIResource `json:"damage_class"`
+	Meta        *MoveMeta        `json:"meta"`
+}
+
+// MoveMeta holds the secondary effects of a move
+type MoveMeta struct {
+	// Ailment is the status condition the move may cause, "none" for none
+	Ailment NamedAPIResource `json:"ailment"`
+	// AilmentChance is the percent chance of causing the ailment
+	AilmentChance int `json:"ailment_chance"`
+}
+
+// Type represents the response from the type endpoint
+type Type struct {
+	ID                  int                 `json:"id"`
+	Name                string              `json:"name"`
+	Generation          NamedAPIResource    `json:"generation"`
+	DamageRelations     TypeDamageRelations `json:"damage_relations"`
+	PastDamageRelations []struct {
+		Generation      NamedAPIResource    `json:"generation"`
+		DamageRelations TypeDamageRelations `json:"damage_relations"`
+	} `json:"past_damage_relations"`
+}
+
+// TypeDamageRelations lists the types a type is strong or weak against
+type TypeDamageRelations struct {
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+}
+
+// Nature represents the response from the nature endpoint
+type Nature struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	IncreasedStat *NamedAPIResource `json:"increased_stat"`
+	DecreasedStat *NamedAPIResource `json:"decreased_stat"`
+}
+
+// GrowthRate represents the response from the growth-rate endpoint
+type GrowthRate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+// EvolutionChain represents the response from the evolution-chain endpoint
+type EvolutionChain struct {
+	ID    int                `json:"id"`
+	Chain EvolutionChainLink `json:"chain"`
+}
+
+// EvolutionChainLink is one species of an evolution chain and what it evolves into
+type EvolutionChainLink struct {
+	IsBaby           bool                 `json:"is_baby"`
+	Species          NamedAPIResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail    `json:"evolution_details"`
+	EvolvesTo        []EvolutionChainLink `json:"evolves_to"`
+}
+
+// EvolutionDetail lists the conditions of one way of evolving into a species
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	Gender                *int              `json:"gender"`
+	MinLevel              *int              `json:"min_level"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinBeauty             *int              `json:"min_beauty"`
+	MinAffection          *int              `json:"min_affection"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+	TimeOfDay             string            `json:"time_of_day"`
+}
+
+// Pokedex represents the response from the pokedex endpoint
+type Pokedex struct {
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	IsMainSeries   bool              `json:"is_main_series"`
+	Region         *NamedAPIResource `json:"region"`
+	PokemonEntries []PokemonEntry    `json:"pokemon_entries"`
+}
+
+// PokemonEntry is a species and its number in a Pokedex
+type PokemonEntry struct {
+	EntryNumber    int              `json:"entry_number"`
+	PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+}
+
+// Region represents the response from the region endpoint
+type Region struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	MainGeneration *NamedAPIResource  `json:"main_generation"`
+	Locations      []NamedAPIResource `json:"locations"`
+	Pokedexes      []NamedAPIResource `json:"pokedexes"`
+	VersionGroups  []NamedAPIResource `json:"version_groups"`
+}
+
+// Location represents the response from the location endpoint: a place of
+// a region, made of one or more location areas
+type Location struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Region *NamedAPIResource  `json:"region"`
+	Areas  []NamedAPIResource `json:"areas"`
 }
@@ -0,0 +1,46 @@
+package pokeapi
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultRequestsPerSecond keeps the client within PokeAPI's fair use policy
+const defaultRequestsPerSecond = 20
+
+// RateLimiter is a token bucket allowing bursts of up to perSecond requests,
+// refilled at perSecond tokens per second. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a rate limiter allowing perSecond requests per second
+func NewRateLimiter(perSecond int) *RateLimiter {
+	return &RateLimiter{
+		rate:   float64(perSecond),
+		tokens: float64(perSecond),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be made
+func (l *RateLimiter) Wait() {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		time.Sleep(wait)
+	}
+}
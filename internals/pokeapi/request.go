@@ -0,0 +1,47 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNotFound is returned when the API has no resource at the requested URL,
+// e.g. for a misspelled name
+var ErrNotFound = errors.New("not found")
+
+// get fetches url (from the cache when possible) and decodes the JSON body into v
+func (c *Client) get(url string, v any) error {
+	if cachedData, found := c.Cache.Get(url); found {
+		return json.Unmarshal(cachedData, v)
+	}
+
+	if c.Limiter != nil {
+		c.Limiter.Wait()
+	}
+
+	res, err := c.HTTPClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", url, ErrNotFound)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, url)
+	}
+
+	// Only cache successful responses
+	c.Cache.Add(url, body)
+
+	return json.Unmarshal(body, v)
+}
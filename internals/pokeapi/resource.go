@@ -0,0 +1,55 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBaseURL is the root of the public PokeAPI
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
+// resolveURL points a resource URL found in an API response at the
+// client's BaseURL, so links keep working against a mirror or a test server
+func (c *Client) resolveURL(url string) string {
+	if c.BaseURL != DefaultBaseURL && strings.HasPrefix(url, DefaultBaseURL) {
+		return c.BaseURL + strings.TrimPrefix(url, DefaultBaseURL)
+	}
+	return url
+}
+
+// GetByID fetches the resource numbered id from endpoint (e.g. "pokemon",
+// "move", "type") into its model T
+func GetByID[T any](c *Client, endpoint string, id int) (*T, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid %s ID %d", endpoint, id)
+	}
+
+	url := fmt.Sprintf("%s/%s/%d", c.BaseURL, endpoint, id)
+
+	var v T
+	if err := c.get(url, &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Follow fetches the resource a NamedAPIResource links to into its model
+// T, e.g. Follow[Type](c, pokemon.Types[0].Type)
+func Follow[T any](c *Client, resource NamedAPIResource) (*T, error) {
+	if resource.URL == "" {
+		return nil, fmt.Errorf("%s has no URL to follow", resource.Name)
+	}
+
+	var v T
+	if err := c.get(c.resolveURL(resource.URL), &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// GetPokemonByID retrieves a Pokemon by its ID, the national dex number for default forms
+func (c *Client) GetPokemonByID(id int) (*Pokemon, error) {
+	return GetByID[Pokemon](c, "pokemon", id)
+}
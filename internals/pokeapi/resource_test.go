@@ -0,0 +1,74 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newResourceServer(t *testing.T) *Client {
+	t.Helper()
+
+	routes := map[string]string{
+		"/pokemon/25": `{"id": 25, "name": "pikachu", "types": [{"slot": 1, "type": {"name": "electric", "url": "https://pokeapi.co/api/v2/type/13/"}}]}`,
+		"/type/13/":   `{"id": 13, "name": "electric"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient()
+	client.BaseURL = server.URL
+	return client
+}
+
+func TestGetByIDAndFollow(t *testing.T) {
+	client := newResourceServer(t)
+
+	pikachu, err := client.GetPokemonByID(25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pikachu.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pikachu.Name)
+	}
+
+	// The type URL points at the public API and is resolved against BaseURL
+	electric, err := Follow[Type](client, pikachu.Types[0].Type)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if electric.ID != 13 || electric.Name != "electric" {
+		t.Errorf("expected the electric type, got %+v", electric)
+	}
+
+	if _, err := GetByID[Move](client, "move", 0); err == nil {
+		t.Error("expected an error for ID 0")
+	}
+	if _, err := GetByID[Move](client, "move", 1); err == nil {
+		t.Error("expected an error for a missing resource")
+	}
+	if _, err := Follow[Type](client, NamedAPIResource{Name: "fire"}); err == nil {
+		t.Error("expected an error following a resource without URL")
+	}
+}
+
+func TestNamedAPIResourceID(t *testing.T) {
+	cases := map[string]int{
+		"https://pokeapi.co/api/v2/pokemon-species/25/": 25,
+		"https://pokeapi.co/api/v2/pokemon/10100":       10100,
+		"": 0,
+	}
+	for url, id := range cases {
+		if actual := (NamedAPIResource{URL: url}).ID(); actual != id {
+			t.Errorf("%q: expected ID %d, got %d", url, id, actual)
+		}
+	}
+}
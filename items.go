@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const defaultBall = "poke-ball"
+
+// itemKind groups items by what they do in the game
+type itemKind int
+
+const (
+	itemBall itemKind = iota
+	itemMedicine
+	itemBerry
+	itemEvolution
+)
+
+// itemEffect describes how a supported item behaves. The item data itself
+// (cost, description...) comes from the /item endpoint.
+type itemEffect struct {
+	kind      itemKind
+	ballBonus float64 // catch multiplier for balls
+	heal      int     // HP restored by medicine and berries, -1 for a full heal
+}
+
+// supportedItems lists the items that can be held in the bag
+var supportedItems = map[string]itemEffect{
+	"poke-ball":     {kind: itemBall, ballBonus: 1},
+	"great-ball":    {kind: itemBall, ballBonus: 1.5},
+	"ultra-ball":    {kind: itemBall, ballBonus: 2},
+	"master-ball":   {kind: itemBall, ballBonus: 255},
+	"potion":        {kind: itemMedicine, heal: 20},
+	"super-potion":  {kind: itemMedicine, heal: 60},
+	"hyper-potion":  {kind: itemMedicine, heal: 120},
+	"max-potion":    {kind: itemMedicine, heal: -1},
+	"oran-berry":    {kind: itemBerry, heal: 10},
+	"sitrus-berry":  {kind: itemBerry, heal: 30},
+	"fire-stone":    {kind: itemEvolution},
+	"water-stone":   {kind: itemEvolution},
+	"thunder-stone": {kind: itemEvolution},
+	"leaf-stone":    {kind: itemEvolution},
+	"moon-stone":    {kind: itemEvolution},
+	"sun-stone":     {kind: itemEvolution},
+	"shiny-stone":   {kind: itemEvolution},
+	"dusk-stone":    {kind: itemEvolution},
+	"dawn-stone":    {kind: itemEvolution},
+	"ice-stone":     {kind: itemEvolution},
+	"kings-rock":    {kind: itemEvolution},
+	"metal-coat":    {kind: itemEvolution},
+	"dragon-scale":  {kind: itemEvolution},
+	"up-grade":      {kind: itemEvolution},
+	"everstone":     {kind: itemEvolution},
+}
+
+// starterInventory is handed out when a new game starts
+var starterInventory = map[string]int{
+	"poke-ball": 10,
+	"potion":    2,
+}
+
+// normalizeItemName turns "Great Ball" or "great_ball" into "great-ball"
+func normalizeItemName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", "-")
+	return strings.Join(strings.Fields(name), "-")
+}
+
+// addItem puts qty units of item in the bag
+func addItem(config *pokeapi.Config, item string, qty int) {
+	if config.Inventory == nil {
+		config.Inventory = make(map[string]int)
+	}
+	config.Inventory[item] += qty
+}
+
+// removeItem takes qty units of item out of the bag
+func removeItem(config *pokeapi.Config, item string, qty int) error {
+	if config.Inventory[item] < qty {
+		return fmt.Errorf("you don't have enough %s (have %d, need %d)", item, config.Inventory[item], qty)
+	}
+
+	config.Inventory[item] -= qty
+	if config.Inventory[item] == 0 {
+		delete(config.Inventory, item)
+	}
+
+	return nil
+}
+
+// commandBag lists the items held in the inventory. During a battle,
+// bag <item> uses the item instead.
+func commandBag(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if currentBattle != nil && len(args) > 0 {
+		return battleUseItem(client, config, normalizeItemName(strings.Join(args, " ")))
+	}
+
+	if len(config.Inventory) == 0 {
+		fmt.Println("Your bag is empty.")
+		return nil
+	}
+
+	names := make([]string, 0, len(config.Inventory))
+	for name := range config.Inventory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("Your bag:")
+	for _, name := range names {
+		fmt.Printf(" - %s x%d\n", name, config.Inventory[name])
+	}
+
+	return nil
+}
+
+// currentHP returns the HP owned has left out of its HP stat
+func currentHP(owned *pokeapi.OwnedPokemon) int {
+	return max(owned.Stats["hp"]-owned.Damage, 0)
+}
+
+// healAmount returns the HP medicine or a berry restores to a Pokemon
+// with hp out of maxHP
+func healAmount(effect itemEffect, hp, maxHP int) int {
+	if effect.heal < 0 || hp+effect.heal > maxHP {
+		return maxHP - hp
+	}
+	return effect.heal
+}
+
+// commandUse uses an item from the bag on the Pokemon with the given ID:
+// medicine and berries heal it, evolution items evolve it. In battle the
+// item is used on the active Pokemon by default, and balls are thrown at
+// the foe. Usage: use <item> [id]
+func commandUse(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	// The ID comes last, after an item name that may have several words
+	itemName, id := normalizeItemName(strings.Join(args, " ")), ""
+	if n := len(args); n > 1 {
+		if _, err := strconv.Atoi(args[n-1]); err == nil {
+			itemName, id = normalizeItemName(strings.Join(args[:n-1], " ")), args[n-1]
+		}
+	}
+	if config.Inventory[itemName] == 0 {
+		return missingItemError(client, config, itemName)
+	}
+
+	kind := supportedItems[itemName].kind
+	switch {
+	case kind == itemMedicine || kind == itemBerry:
+		return useMedicine(client, config, itemName, id)
+	case currentBattle != nil:
+		return battleUseItem(client, config, itemName)
+	case kind == itemEvolution && id != "":
+		return commandEvolve(client, config, []string{id, itemName}, flags)
+	}
+
+	// Nothing to use the item on: show what it does and how to use it
+	item, err := client.GetItem(itemName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", item.Name, item.ShortEffect())
+	switch kind {
+	case itemBall:
+		fmt.Printf("Throw it with: catch <pokemon_name> with %s\n", itemName)
+	case itemEvolution:
+		fmt.Printf("Use it on a Pokemon with: use %s <id>, or let it hold it with: give <id> %s\n", itemName, itemName)
+	}
+
+	return nil
+}
+
+// useMedicine heals the caught Pokemon with ID id with a medicine or berry
+// from the bag. In battle it heals a Pokemon of the team, the active one
+// when id is empty, and spends the turn.
+func useMedicine(client *pokeapi.Client, config *pokeapi.Config, itemName, id string) error {
+	if id == "" && currentBattle == nil {
+		return fmt.Errorf("which Pokemon should get the %s? Usage: %s", itemName, commandLists["use"].usageLine())
+	}
+	if id == "" {
+		return battleUseItem(client, config, itemName)
+	}
+
+	owned, err := parseOwnedID(config, id)
+	if err != nil {
+		return err
+	}
+
+	if b := currentBattle; b != nil {
+		for _, member := range b.team {
+			if member.owned == owned {
+				return b.heal(client, config, member, itemName)
+			}
+		}
+		return fmt.Errorf("%s is not in your team", owned.Name())
+	}
+
+	hp, maxHP := currentHP(owned), owned.Stats["hp"]
+	if hp == maxHP {
+		return fmt.Errorf("%s is already at full health", owned.Name())
+	}
+	if err := removeItem(config, itemName, 1); err != nil {
+		return err
+	}
+
+	healed := healAmount(supportedItems[itemName], hp, maxHP)
+	owned.Damage -= healed
+	fmt.Printf("%s recovered %d HP. HP %d/%d\n", owned.Name(), healed, hp+healed, maxHP)
+
+	return nil
+}
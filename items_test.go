@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestCommandUseMedicine(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	client := newTestClient(t, map[string]string{})
+	config := &pokeapi.Config{Inventory: map[string]int{"potion": 2, "oran-berry": 1}}
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Stats: map[string]int{"hp": 50}, Damage: 30})
+	owned := config.CaughtPokemon[1]
+
+	if err := commandUse(client, config, []string{"potion", "1"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Damage != 10 || config.Inventory["potion"] != 1 {
+		t.Errorf("expected 20 HP healed with one potion, got %d damage and %d potions", owned.Damage, config.Inventory["potion"])
+	}
+
+	// A berry heals no more than the HP lost
+	if err := commandUse(client, config, []string{"oran", "berry", "1"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Damage != 0 || config.Inventory["oran-berry"] != 0 {
+		t.Errorf("expected the berry to heal fully, got %d damage and %d berries", owned.Damage, config.Inventory["oran-berry"])
+	}
+
+	if err := commandUse(client, config, []string{"potion", "1"}, nil); err == nil {
+		t.Errorf("expected an error healing a Pokemon at full health")
+	}
+	if err := commandUse(client, config, []string{"potion"}, nil); err == nil {
+		t.Errorf("expected an error without a Pokemon outside of battle")
+	}
+	if config.Inventory["potion"] != 1 {
+		t.Errorf("expected the potion to be kept, got %d", config.Inventory["potion"])
+	}
+}
+
+func TestCommandUseInBattle(t *testing.T) {
+	client := newTestClient(t, map[string]string{})
+	config := &pokeapi.Config{Inventory: map[string]int{"potion": 1}}
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Stats: map[string]int{"hp": 50}})
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Stats: map[string]int{"hp": 50}, Damage: 25})
+
+	// The foe knows no move, so it only struggles after the potion
+	foe := &battler{owned: &pokeapi.OwnedPokemon{Level: 1, Stats: map[string]int{"hp": 50}}, hp: 50, maxHP: 50}
+	b := &battle{r: rand.New(rand.NewSource(1)), foe: foe, generation: latestGeneration}
+	for _, owned := range partyPokemon(config) {
+		b.team = append(b.team, &battler{owned: owned, hp: currentHP(owned), maxHP: owned.Stats["hp"]})
+	}
+	currentBattle = b
+	t.Cleanup(func() { currentBattle = nil })
+
+	if err := commandUse(client, config, []string{"potion", "2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.team[1].hp != 45 || config.Inventory["potion"] != 0 {
+		t.Errorf("expected the second Pokemon to be healed, got %d HP and %d potions", b.team[1].hp, config.Inventory["potion"])
+	}
+
+	// The HP lost is kept once the battle is over
+	b.end()
+	if lost := config.CaughtPokemon[2].Damage; lost != 5 {
+		t.Errorf("expected 5 HP lost after the battle, got %d", lost)
+	}
+}
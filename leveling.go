@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// maxLevel is the highest level a Pokemon can reach
+const maxLevel = 100
+
+// experienceForLevel returns the total experience needed to reach level
+func experienceForLevel(growthRate *pokeapi.GrowthRate, level int) int {
+	for _, l := range growthRate.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// levelForExperience returns the level reached with experience
+func levelForExperience(growthRate *pokeapi.GrowthRate, experience int) int {
+	level := 1
+	for _, l := range growthRate.Levels {
+		if l.Experience <= experience && l.Level > level {
+			level = l.Level
+		}
+	}
+	return level
+}
+
+// experienceYield returns the experience earned for defeating or catching a
+// Pokemon: base experience times level over 7, one and a half times more
+// against a trainer's Pokemon
+func experienceYield(baseExperience, level int, fromTrainer bool) int {
+	exp := baseExperience * level / 7
+	if fromTrainer {
+		exp = exp * 3 / 2
+	}
+	return max(exp, 1)
+}
+
+// growthRateOf fetches the growth rate of the species of pokemon
+func growthRateOf(client *pokeapi.Client, pokemon pokeapi.PokemonSummary) (*pokeapi.GrowthRate, error) {
+	species, err := client.GetPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetGrowthRate(species.GrowthRate.Name)
+}
+
+// movesLearnedAt returns the moves pokemon learns by level-up exactly at level
+func movesLearnedAt(pokemon *pokeapi.Pokemon, level int) []string {
+	var names []string
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name == "level-up" && detail.LevelLearnedAt == level {
+				names = append(names, move.Move.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// learnMove teaches moveName to owned, forgetting its oldest move when it
+// already knows maxMoves
+func learnMove(owned *pokeapi.OwnedPokemon, moveName string) {
+	if knowsMove(owned, moveName) {
+		return
+	}
+
+	if len(owned.Moves) < maxMoves {
+		owned.Moves = append(owned.Moves, moveName)
+		fmt.Printf("%s learned %s!\n", owned.Name(), moveName)
+		return
+	}
+
+	forgotten := owned.Moves[0]
+	owned.Moves = append(owned.Moves[1:], moveName)
+	fmt.Printf("1, 2, and... Poof! %s forgot %s and learned %s!\n", owned.Name(), forgotten, moveName)
+}
+
+// gainExperience gives exp to owned, leveling it up as many times as its
+// growth rate allows: stats are recalculated, new level-up moves learned,
+// and it evolves if it meets the conditions
+func gainExperience(client *pokeapi.Client, config *pokeapi.Config, owned *pokeapi.OwnedPokemon, exp int) error {
+	if owned.Level >= maxLevel {
+		return nil
+	}
+
+	growthRate, err := growthRateOf(client, owned.Pokemon)
+	if err != nil {
+		return err
+	}
+
+	// Pokemon from older saves have no experience recorded yet
+	owned.Experience = max(owned.Experience, experienceForLevel(growthRate, owned.Level)) + exp
+	fmt.Printf("%s gained %d Exp. Points!\n", owned.Name(), exp)
+
+	newLevel := min(levelForExperience(growthRate, owned.Experience), maxLevel)
+	if newLevel <= owned.Level {
+		return nil
+	}
+
+	// The learnset isn't kept with the Pokemon
+	pokemon, err := client.Catch(owned.Pokemon.Name)
+	if err != nil {
+		return err
+	}
+
+	for owned.Level < newLevel {
+		owned.Level++
+		owned.Happiness = min(owned.Happiness+levelUpHappiness, maxHappiness)
+		fmt.Printf("%s grew to level %d!\n", owned.Name(), owned.Level)
+
+		// Only damaging moves are learned, the battle engine ignores status moves
+		moves, err := loadBattleMoves(client, movesLearnedAt(pokemon, owned.Level))
+		if err != nil {
+			return err
+		}
+		for _, m := range moves {
+			learnMove(owned, m.name)
+		}
+	}
+
+	if err := refreshStats(client, owned); err != nil {
+		return err
+	}
+
+	_, err = tryEvolve(client, config, owned, triggerLevelUp, "")
+	return err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// mediumFastLevels returns the levels of the medium-fast growth rate (level^3) up to level 10
+func mediumFastLevels() string {
+	levels := make([]string, 0, 10)
+	for level := 1; level <= 10; level++ {
+		levels = append(levels, fmt.Sprintf(`{"level": %d, "experience": %d}`, level, level*level*level))
+	}
+	return `{"name": "medium", "levels": [` + strings.Join(levels, ",") + `]}`
+}
+
+func TestLevelForExperience(t *testing.T) {
+	var growthRate pokeapi.GrowthRate
+	if err := json.Unmarshal([]byte(mediumFastLevels()), &growthRate); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		experience int
+		level      int
+	}{
+		{experience: 0, level: 1},
+		{experience: 125, level: 5},
+		{experience: 215, level: 5},
+		{experience: 216, level: 6},
+	}
+	for _, c := range cases {
+		if actual := levelForExperience(&growthRate, c.experience); actual != c.level {
+			t.Errorf("%d exp: expected level %d, got %d", c.experience, c.level, actual)
+		}
+	}
+	if exp := experienceForLevel(&growthRate, 6); exp != 216 {
+		t.Errorf("expected 216 exp for level 6, got %d", exp)
+	}
+}
+
+func TestExperienceYield(t *testing.T) {
+	// A level 10 pidgey (base experience 50)
+	if exp := experienceYield(50, 10, false); exp != 71 {
+		t.Errorf("expected 71 exp from a wild Pokemon, got %d", exp)
+	}
+	if exp := experienceYield(50, 10, true); exp != 106 {
+		t.Errorf("expected 106 exp from a trainer's Pokemon, got %d", exp)
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	charmander := `{
+		"name": "charmander",
+		"species": {"name": "charmander"},
+		"stats": [{"base_stat": 39, "stat": {"name": "hp"}}],
+		"moves": [
+			{"move": {"name": "ember"}, "version_group_details": [{"level_learned_at": 7, "move_learn_method": {"name": "level-up"}}]},
+			{"move": {"name": "smokescreen"}, "version_group_details": [{"level_learned_at": 6, "move_learn_method": {"name": "level-up"}}]}
+		]
+	}`
+	client := newTestClient(t, map[string]string{
+		"/pokemon/charmander":         charmander,
+		"/pokemon-species/charmander": `{"name": "charmander", "growth_rate": {"name": "medium"}}`,
+		"/growth-rate/medium":         mediumFastLevels(),
+		"/nature/hardy":               `{"name": "hardy"}`,
+		"/move/ember":                 `{"name": "ember", "power": 40, "pp": 25, "type": {"name": "fire"}, "damage_class": {"name": "special"}}`,
+		"/move/smokescreen":           `{"name": "smokescreen", "power": null, "pp": 20, "type": {"name": "normal"}, "damage_class": {"name": "status"}}`,
+	})
+
+	// The learnset is fetched, only the summary is kept with the Pokemon
+	var pokemon pokeapi.PokemonSummary
+	if err := json.Unmarshal([]byte(charmander), &pokemon); err != nil {
+		t.Fatal(err)
+	}
+
+	owned := &pokeapi.OwnedPokemon{
+		Level:      5,
+		Experience: 125,
+		Nature:     "hardy",
+		Moves:      []string{"scratch", "growl", "tackle", "leer"},
+		Pokemon:    pokemon,
+	}
+
+	// 125 + 250 = 375 exp reaches level 7 (343 exp)
+	config := &pokeapi.Config{}
+	if err := gainExperience(client, config, owned, 250); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Level != 7 || owned.Experience != 375 {
+		t.Errorf("expected level 7 with 375 exp, got level %d with %d exp", owned.Level, owned.Experience)
+	}
+	if owned.Stats["hp"] != calcHP(39, 0, 0, 7) {
+		t.Errorf("expected stats to be recalculated, got %d HP", owned.Stats["hp"])
+	}
+
+	// Ember replaces the oldest move, smokescreen is not a damaging move
+	expected := []string{"growl", "tackle", "leer", "ember"}
+	if strings.Join(owned.Moves, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected moves %v, got %v", expected, owned.Moves)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// listedLocations and listedAreas are the numbered listings last printed by
+// the locations command and the map or areas commands, so a name can be
+// picked by its number
+var (
+	listedLocations []string
+	listedAreas     []string
+)
+
+// fromListing returns the name numbered param in listing, or param itself
+// when it is not a number
+func fromListing(listing []string, param, command string) (string, error) {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return param, nil
+	}
+	if n < 1 || n > len(listing) {
+		return "", fmt.Errorf("no entry #%d in the current listing. List them first with: %s", n, command)
+	}
+	return listing[n-1], nil
+}
+
+// printListing prints names as a numbered list
+func printListing(names []string) {
+	for i, name := range names {
+		fmt.Printf(" %d. %s\n", i+1, name)
+	}
+}
+
+// commandLocations lists the locations of a region by number. Usage: locations <region>
+func commandLocations(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	region, err := client.GetRegion(args[0])
+	if err != nil {
+		return err
+	}
+
+	listedLocations = make([]string, len(region.Locations))
+	for i, location := range region.Locations {
+		listedLocations[i] = location.Name
+	}
+
+	fmt.Printf("Locations of %s:\n", region.Name)
+	printListing(listedLocations)
+	fmt.Println("See the areas of one with: areas <number|location>")
+
+	return nil
+}
+
+// commandAreas lists the location areas of a location by number, the
+// location being a name or its number in the last locations listing.
+// Usage: areas <number|location>
+func commandAreas(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	name, err := fromListing(listedLocations, args[0], "locations <region>")
+	if err != nil {
+		return err
+	}
+
+	location, err := client.GetLocation(name)
+	if err != nil {
+		return err
+	}
+	if len(location.Areas) == 0 {
+		fmt.Printf("%s has no areas with Pokemon.\n", location.Name)
+		return nil
+	}
+
+	listedAreas = make([]string, len(location.Areas))
+	for i, area := range location.Areas {
+		listedAreas[i] = area.Name
+	}
+
+	fmt.Printf("Areas of %s:\n", location.Name)
+	printListing(listedAreas)
+	fmt.Println("Explore one with: explore <number|area_name>")
+
+	return nil
+}
+
+// commandGoto moves the player to a location area, given by name or by its
+// number in the last map or areas listing. Usage: goto <number|area_name>
+func commandGoto(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		if config.Location == "" {
+			fmt.Printf("You are not in any area yet. Usage: %s\n", commandLists["goto"].usageLine())
+		} else {
+			fmt.Printf("You are in %s\n", config.Location)
+		}
+		return nil
+	}
+
+	areaName, err := fromListing(listedAreas, args[0], "map or areas <location>")
+	if err != nil {
+		return err
+	}
+
+	// Make sure the area exists before moving there
+	area, err := client.Explore(areaName)
+	if err != nil {
+		return withSuggestions(client, "location-area", areaName, err)
+	}
+
+	config.Location = area.Name
+	wild = nil
+	fmt.Printf("You travelled to %s.\n", area.Name)
+
+	return nil
+}
+
+// ensureCatchableHere checks that pokemonName can be met in the current location area
+func ensureCatchableHere(client *pokeapi.Client, config *pokeapi.Config, pokemonName string) error {
+	if config.FreeCatch {
+		return nil
+	}
+
+	if config.Location == "" {
+		return fmt.Errorf("you are not in any area. Travel somewhere first with: goto <area_name>")
+	}
+
+	area, err := client.Explore(config.Location)
+	if err != nil {
+		return err
+	}
+
+	for _, encounter := range area.PokemonEncounters {
+		if encounter.Pokemon.Name == pokemonName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("there is no %s in %s", pokemonName, config.Location)
+}
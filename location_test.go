@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// newTestClient returns a client talking to a fake PokeAPI that serves
+// the given JSON bodies by URL path and 404s everything else
+func newTestClient(t *testing.T, routes map[string]string) *pokeapi.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	client := pokeapi.NewClient()
+	client.BaseURL = server.URL
+	return client
+}
+
+func TestEnsureCatchableHere(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/location-area/viridian-forest-area": `{
+			"name": "viridian-forest-area",
+			"pokemon_encounters": [
+				{"pokemon": {"name": "caterpie"}},
+				{"pokemon": {"name": "pikachu"}}
+			]
+		}`,
+	})
+
+	config := &pokeapi.Config{}
+	if err := ensureCatchableHere(client, config, "pikachu"); err == nil {
+		t.Errorf("expected error when not in any area")
+	}
+
+	if err := commandGoto(client, config, []string{"viridian-forest-area"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ensureCatchableHere(client, config, "pikachu"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ensureCatchableHere(client, config, "mewtwo"); err == nil {
+		t.Errorf("expected error for a Pokemon not living in the area")
+	}
+
+	config.FreeCatch = true
+	if err := ensureCatchableHere(client, config, "mewtwo"); err != nil {
+		t.Errorf("unexpected error in free-catch mode: %v", err)
+	}
+}
+
+func TestBrowseLocations(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/region/kanto":                       `{"name": "kanto", "locations": [{"name": "pallet-town"}, {"name": "viridian-forest"}]}`,
+		"/location/viridian-forest":           `{"name": "viridian-forest", "areas": [{"name": "viridian-forest-area"}]}`,
+		"/location-area/viridian-forest-area": `{"name": "viridian-forest-area"}`,
+	})
+	t.Cleanup(func() { listedLocations, listedAreas = nil, nil })
+
+	config := &pokeapi.Config{}
+	if err := commandAreas(client, config, []string{"2"}, nil); err == nil {
+		t.Error("expected an error picking a number before listing the locations")
+	}
+	if err := commandLocations(client, config, []string{"kanto"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandAreas(client, config, []string{"2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandGoto(client, config, []string{"1"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Location != "viridian-forest-area" {
+		t.Errorf("expected to travel to viridian-forest-area, got %q", config.Location)
+	}
+	if err := commandExplore(client, config, []string{"2"}, nil); err == nil {
+		t.Error("expected an error for a number outside of the listing")
+	}
+}
+
+func TestMapPaging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var results []string
+		for i := offset; i < min(offset+mapPageSize, 25); i++ {
+			results = append(results, fmt.Sprintf(`{"name": "area-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"count": 25, "results": [%s]}`, strings.Join(results, ","))
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { mapCursor, listedAreas = nil, nil })
+
+	client := pokeapi.NewClient()
+	client.BaseURL = server.URL
+	config := &pokeapi.Config{}
+
+	if err := commandMapb(client, config, nil, nil); err == nil {
+		t.Error("expected an error going back before the first page")
+	}
+	if err := commandMap(client, config, []string{"9223372036854775807"}, nil); err == nil {
+		t.Error("expected an error jumping to a huge page before any page is shown")
+	}
+	if err := commandMap(client, config, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandMapb(client, config, nil, nil); err == nil {
+		t.Error("expected an error going back from the first page")
+	}
+	if err := commandMap(client, config, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapCursor.Number() != 2 || listedAreas[0] != "area-20" {
+		t.Errorf("expected page 2 starting at area-20, got page %d starting at %s", mapCursor.Number(), listedAreas[0])
+	}
+	if err := commandMap(client, config, nil, nil); err == nil {
+		t.Error("expected an error going past the last page")
+	}
+	if err := commandMap(client, config, []string{"3"}, nil); err == nil {
+		t.Error("expected an error jumping past the last page")
+	}
+	if err := commandMap(client, config, []string{"9223372036854775807"}, nil); err == nil {
+		t.Error("expected an error jumping to a page whose offset overflows")
+	}
+	if err := commandMap(client, config, []string{"1"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapCursor.Number() != 1 {
+		t.Errorf("expected to jump back to page 1, got page %d", mapCursor.Number())
+	}
+}
+
+func TestCommandRegions(t *testing.T) {
+	routes := map[string]string{
+		"/region":       `{"count": 2, "results": [{"name": "kanto"}, {"name": "johto"}]}`,
+		"/region/kanto": `{"name": "kanto", "locations": [{"name": "pallet-town"}]}`,
+	}
+	client := newTestClient(t, routes)
+
+	if err := commandRegions(client, &pokeapi.Config{}, nil, nil); err == nil {
+		t.Errorf("expected an error when a region can't be fetched")
+	}
+
+	routes["/region/johto"] = `{"name": "johto", "locations": [{"name": "new-bark-town"}]}`
+	if err := commandRegions(client, &pokeapi.Config{}, nil, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
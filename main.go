@@ -1,228 +1,302 @@
 package main
 
 import (
-	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/pannipasra/pokedexcli/internals/lineedit"
 	"github.com/pannipasra/pokedexcli/internals/pokeapi"
 )
 
-type cliCommand struct {
-	name        string
-	description string
-	callback    func(client *pokeapi.Client, config *pokeapi.Config, param string) error
-}
-
 var commandLists map[string]cliCommand
 
+// mapPageSize is how many location areas map and mapb show at once
+const mapPageSize = 20
+
+// mapCursor is the page of location areas last shown by map or mapb, nil before the first
+var mapCursor *pokeapi.Page[pokeapi.LocationAreaResult]
+
+// stdin reads the player's input, shared by the REPL and the confirmation prompts
+var stdin = lineedit.New(os.Stdin, os.Stdout)
+
 func main() {
-	// Create a scanner that reads from standard input (os.Stdin)
-	scanner := bufio.NewScanner(os.Stdin)
+	freeCatch := flag.Bool("free-catch", false, "sandbox mode: catch any Pokemon from anywhere")
+	historySize := flag.Int("history-size", defaultHistorySize, "number of commands kept in the history")
+	flag.Parse()
+
 	prompt := "Pokedex > "
 
 	// Initiate PokeAPI client and config
 	client := pokeapi.NewClient()
-	config := &pokeapi.Config{
-		Next:     nil,
-		Previous: nil,
-	}
-
-	commandLists = map[string]cliCommand{
-		"exit": {
-			name:        "exit",
-			description: "Exit the Pokedex",
-			callback:    commandExit,
-		},
-		"help": {
-			name:        "help",
-			description: "Displays a help message",
-			callback:    commandHelp,
-		},
-		"map": {
-			name:        "map",
-			description: "Displays 20 Pokémon next locations per map call.",
-			callback:    commandMap,
-		},
-		"mapb": {
-			name:        "mapb",
-			description: "Displays 20 Pokémon previous locations per map call.",
-			callback:    commandMapb,
-		},
-		"explore": {
-			name:        "explore",
-			description: "Explore a location area for Pokémon. Usage: explore <area_name>",
-			callback:    commandExplore,
-		},
-		"catch": {
-			name:        "catch",
-			description: "Catching Pokemon by name. Usage: catch <pokemon_name>",
-			callback:    commandCatch,
-		},
-		"inspect": {
-			name:        "inspect",
-			description: "It takes the name of a Pokemon and prints the name, height, weight, stats and type(s) of the Pokemon. Usage: inspect <pokemon_name>",
-			callback:    commandInspect,
-		},
-		"pokedex": {
-			name:        "pokedex",
-			description: "It takes no arguments but prints a list of all the names of the Pokemon the user has caught",
-			callback:    commandPokedex,
-		},
+	config := &pokeapi.Config{}
+
+	// Restore the previous session, or start a new game
+	savePath, err := saveFilePath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error locating save file:", err)
+	} else if err := loadGame(client, savePath, config); err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading save file:", err)
+		// Autosaving would replace the save file with the new game
+		fmt.Fprintln(os.Stderr, "The game won't be saved this session, so the save file is kept.")
+		savePath = ""
 	}
+	config.FreeCatch = *freeCatch
 
-	for {
-		fmt.Print(prompt)
+	// Recall the commands of the previous sessions
+	stdin.History = lineedit.NewHistory(*historySize)
+	historyPath, err := historyFilePath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error locating history file:", err)
+	} else if err := loadHistory(historyPath, stdin.History); err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading history:", err)
+	}
 
-		// Use Scan() to read the next line of input
-		if scanner.Scan() {
-			// Get text that was read
-			input := scanner.Text()
+	commandLists = commands()
 
-			inputs := cleanInput(input)
+	// Load the aliases and macros defined in the previous sessions
+	if configPath, err := userConfigFilePath(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error locating config file:", err)
+	} else {
+		settings, err := loadUserConfig(configPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error loading config file:", err)
+		}
+		userSettings = settings
+	}
 
-			if len(inputs) == 0 {
-				continue
-			}
+	// Complete command names, and the arguments of the commands taking names
+	stdin.Completer = func(line string) (int, []string) {
+		return completeInput(config, line)
+	}
 
-			commandName := inputs[0]
-			param := ""
-			if len(inputs) > 1 {
-				param = inputs[1]
-			}
+	for {
+		// Read the next line of input, with line editing on a terminal
+		input, err := stdin.ReadLine(prompt)
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			commandExit(client, config, nil, nil)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading input:", err)
+			return
+		}
 
-			// Check if the first word is a command
-			if command, exists := commandLists[commandName]; exists {
-				// Command exists, execute its callback
-				err := command.callback(client, config, param)
-				if err != nil {
-					fmt.Fprintln(os.Stderr, "Error executing command:", err)
+		// Run a command of the history again with !n or !!
+		expanded, err := expandHistory(stdin.History, input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			continue
+		}
+		if expanded != input {
+			fmt.Println(expanded)
+			input = expanded
+		}
+
+		// Only commands typed are recorded, so piping a script leaves the
+		// history alone
+		if stdin.IsTerminal() {
+			stdin.History.Add(input)
+			if historyPath != "" {
+				if err := saveHistory(historyPath, stdin.History); err != nil {
+					fmt.Fprintln(os.Stderr, "Error saving history:", err)
 				}
-			} else {
-				fmt.Println("Unknown command")
 			}
 		}
 
-		// Check for errors
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintln(os.Stderr, "Error reading input:", err)
+		if strings.TrimSpace(input) == "" {
+			continue
 		}
 
+		// Run the command, or the commands of an alias or macro
+		if err := runCommand(client, config, input, 0); err != nil {
+			fmt.Fprintln(os.Stderr, "Error executing command:", err)
+		}
+
+		// Autosave after every command so nothing is lost on exit
+		if savePath != "" {
+			if err := saveGame(savePath, config); err != nil {
+				fmt.Fprintln(os.Stderr, "Error saving game:", err)
+			}
+		}
 	}
 }
 
-func cleanInput(text string) []string {
-	// Trim leading and trailing whitespace
-	text = strings.TrimSpace(text)
-
-	text = strings.ToLower(text)
-
-	// Convert to lowercase and split by whitespace
-	words := strings.Fields(text)
+// commandMap shows the next page of location areas, or the page given.
+// Usage: map [page]
+func commandMap(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 1 {
+		page, err := strconv.Atoi(args[0])
+		if err != nil || page < 1 {
+			return fmt.Errorf("page must be a positive number. Usage: %s", commandLists["map"].usageLine())
+		}
+		// Checked before computing the offset, which a huge page would overflow
+		count, err := locationAreaCount(client)
+		if err != nil {
+			return err
+		}
+		if pages := (count + mapPageSize - 1) / mapPageSize; page > pages {
+			return fmt.Errorf("there are only %d pages", pages)
+		}
+		return showMapPage(client, (page-1)*mapPageSize)
+	}
 
-	return words
-}
+	if mapCursor == nil {
+		return showMapPage(client, 0)
+	}
+	if !mapCursor.HasNext() {
+		return fmt.Errorf("you're on the last page (page %d of %d)", mapCursor.Number(), mapCursor.TotalPages())
+	}
 
-func commandExit(client *pokeapi.Client, config *pokeapi.Config, param string) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil // This line will never execute due to os.Exit
+	return showMapPage(client, mapCursor.Offset+mapCursor.Limit)
 }
 
-func commandHelp(client *pokeapi.Client, config *pokeapi.Config, param string) error {
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-
-	for key, value := range commandLists {
-		fmt.Printf("%v: %v\n", key, value.description)
+// commandMapb shows the previous page of location areas
+func commandMapb(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if mapCursor == nil || !mapCursor.HasPrevious() {
+		return fmt.Errorf("you're on the first page")
 	}
 
-	return nil
+	return showMapPage(client, max(mapCursor.Offset-mapCursor.Limit, 0))
 }
 
-func commandMap(client *pokeapi.Client, config *pokeapi.Config, param string) error {
-	res, err := client.ListLocationAreas(config)
-	if err != nil {
-		return err
+// locationAreaCount returns the number of location areas, from the page
+// last shown or else the first page
+func locationAreaCount(client *pokeapi.Client) (int, error) {
+	if mapCursor != nil {
+		return mapCursor.Count, nil
 	}
-
-	// Print the results
-	for _, result := range res.Results {
-		fmt.Println(result.Name)
+	page, err := client.ListLocationAreas(0, mapPageSize)
+	if err != nil {
+		return 0, err
 	}
-
-	return nil
+	return page.Count, nil
 }
 
-func commandMapb(client *pokeapi.Client, config *pokeapi.Config, param string) error {
-	res, err := client.ListPreviousLocationAreas(config)
+// showMapPage prints the page of location areas starting at offset as a
+// numbered listing and moves the map cursor to it
+func showMapPage(client *pokeapi.Client, offset int) error {
+	page, err := client.ListLocationAreas(offset, mapPageSize)
 	if err != nil {
 		return err
 	}
-	// Print the results
-	for _, result := range res.Results {
-		fmt.Println(result.Name)
+	if len(page.Results) == 0 && page.Offset > 0 {
+		return fmt.Errorf("there are only %d pages", page.TotalPages())
+	}
+
+	mapCursor = page
+	listedAreas = make([]string, len(page.Results))
+	for i, result := range page.Results {
+		listedAreas[i] = result.Name
 	}
 
+	printListing(listedAreas)
+	fmt.Printf("Page %d of %d\n", page.Number(), page.TotalPages())
+
 	return nil
 }
 
-func commandExplore(client *pokeapi.Client, config *pokeapi.Config, locationName string) error {
-	if locationName == "" {
-		return fmt.Errorf("area name is required. Usage: explore <area_name>")
+func commandExplore(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	locationName, err := fromListing(listedAreas, args[0], "map or areas <location>")
+	if err != nil {
+		return err
 	}
 
 	exploreEncounter, err := client.Explore(locationName)
 	if err != nil {
-		return err
+		return withSuggestions(client, "location-area", locationName, err)
+	}
+
+	names := make([]string, len(exploreEncounter.PokemonEncounters))
+	for i, encounter := range exploreEncounter.PokemonEncounters {
+		names[i] = encounter.Pokemon.Name
 	}
 
 	fmt.Printf("Exploring %s...\n", locationName)
+	results := client.GetPokemonBatch(names, batchOptions("Looking up Pokemon", len(names)))
+	gen := activeGeneration(config)
+
 	fmt.Println("Found Pokemon:")
-	for _, pokemon := range exploreEncounter.PokemonEncounters {
-		fmt.Println("-", pokemon.Pokemon.Name)
+	for i, result := range results {
+		if result.Err != nil {
+			fmt.Printf("- %s (couldn't look it up: %v)\n", names[i], result.Err)
+			registerSeen(config, exploreEncounter.PokemonEncounters[i].Pokemon.ID(), names[i])
+			continue
+		}
+		fmt.Printf("- %s (%s)\n", names[i], strings.Join(typesForGeneration(result.Value.Summary(), gen), "/"))
+		registerSeenPokemon(config, result.Value.Summary())
 	}
 
 	return nil
 }
 
-func commandCatch(client *pokeapi.Client, config *pokeapi.Config, pokemonName string) error {
-	if pokemonName == "" {
-		return fmt.Errorf("pokemon name is required. Usage: catch <pokemon_name>")
+func commandCatch(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	// Split "pikachu with great-ball" into the Pokemon and the ball
+	pokemonName, ball := args[0], defaultBall
+	if len(args) > 1 {
+		if args[1] != "with" || len(args) == 2 {
+			return fmt.Errorf("the ball is given after with. Usage: %s", commandLists["catch"].usageLine())
+		}
+		ball = normalizeItemName(strings.Join(args[2:], " "))
+	}
+	if effect, ok := supportedItems[ball]; !ok || effect.kind != itemBall {
+		err := fmt.Errorf("%s is not a Poke Ball", ball)
+		return suggestAmong(client, "item", ball, ballNames(config), err)
+	}
+	if config.Inventory[ball] == 0 {
+		return fmt.Errorf("you have no %s left", ball)
 	}
 
-	pokemon, err := client.Catch(pokemonName)
+	pokemon, err := fetchPokemon(client, pokemonName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	if err := ensureCatchableHere(client, config, pokemon.Name); err != nil {
+		return err
+	}
 
-	pokemonBaseCatchProbability := calculateCatchProbability(pokemon.BaseExperience)
+	registerSeenPokemon(config, pokemon.Summary())
+	fmt.Printf("Throwing a %s at %s...\n", ball, pokemon.Name)
 
 	// Create a new random source r with current time
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	// Using rand.Intn for integer-based random value
-	// We'll use a scale of 100 to represent percentages
-	randomValue := r.Intn(100)
-	pokemonScaledProbability := pokemonBaseCatchProbability * 100
+	// Wild Pokemon met outside of a battle are at full health, with no
+	// status condition: weaken them in a battle for better odds
+	caught, err := throwBall(client, config, r, pokemon.Summary(), ball, 1, 1, "")
+	if err != nil {
+		return err
+	}
 
-	// If random value is less than scaled catch probability, the Pokémon is caught
-	if float64(randomValue) < pokemonScaledProbability {
-		if config.CaughtPokemon == nil {
-			m := make(map[string]pokeapi.Pokemon)
-			config.CaughtPokemon = &m
+	if caught {
+		level, err := catchLevel(client, config, r, pokemon.Name)
+		if err != nil {
+			return err
+		}
+		owned, err := newOwnedPokemon(client, r, pokemon, level)
+		if err != nil {
+			return err
 		}
 
-		// Adding a Pokemon:
-		(*config.CaughtPokemon)[pokemon.Name] = *pokemon
-
-		fmt.Printf("%s was caught!\n", pokemon.Name)
-		fmt.Println("You may now inspect it with the inspect command.")
+		// The lead Pokemon of the party gets experience for the catch
+		team := partyPokemon(config)
+		registerCatch(config, owned)
+		if len(team) > 0 {
+			exp := experienceYield(pokemon.BaseExperience, level, false)
+			if err := gainExperience(client, config, team[0], exp); err != nil {
+				return err
+			}
+		}
 	} else {
 		fmt.Printf("%s escaped!\n", pokemon.Name)
 	}
@@ -259,43 +333,71 @@ func calculateCatchProbability(baseExperience int) float64 {
 	return probability
 }
 
-// It takes the name of a Pokemon and prints the name, height, weight, stats and type(s) of the Pokemon
-func commandInspect(client *pokeapi.Client, config *pokeapi.Config, pokemonName string) error {
-	if pokemonName == "" {
-		return fmt.Errorf("pokemon name is required. Usage: catch <pokemon_name>")
-	}
-
-	if config.CaughtPokemon == nil {
-		fmt.Println("You has not caught the Pokemon, yet.")
-		return nil
-	}
-	pokemon, exists := (*config.CaughtPokemon)[pokemonName]
-	if !exists {
+// It takes the ID or name of a caught Pokemon and prints the name, height, weight, stats and type(s) of the Pokemon
+func commandInspect(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	query := strings.Join(args, " ")
+	matches := findOwnedPokemon(config, query)
+	if len(matches) == 0 {
 		fmt.Println("You has not caught the Pokemon, yet.")
 		return nil
 	}
-	fmt.Printf("Name: %s\nHeight: %v\nWeight: %v\n", pokemon.Name, pokemon.Height, pokemon.Weight)
-	fmt.Printf("Stats:\n")
-	for _, s := range pokemon.Stats {
-		fmt.Printf(" - %s: %v\n", s.Stat.Name, s.BaseStat)
-	}
-	fmt.Printf("Types:\n")
-	for _, t := range pokemon.Types {
-		fmt.Printf(" - %s\n", t.Type.Name)
+
+	for i, owned := range matches {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := printOwnedPokemon(client, config, owned); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func commandPokedex(client *pokeapi.Client, config *pokeapi.Config, pokemonName string) error {
-	if config.CaughtPokemon == nil {
-		fmt.Println("")
-		return nil
+// printOwnedPokemon prints a caught Pokemon with its base and actual stats.
+// The height, weight and abilities of its species are fetched.
+func printOwnedPokemon(client *pokeapi.Client, config *pokeapi.Config, owned *pokeapi.OwnedPokemon) error {
+	pokemon, err := client.Catch(owned.Pokemon.Name)
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("Your Pokedex:")
-	for _, pokemon := range *config.CaughtPokemon {
-		fmt.Printf(" - %s\n", pokemon.Name)
+	fmt.Printf("%s (ID %d, #%04d, Lv. %d, %d Exp. Points)\n", owned.Name(), owned.ID, nationalNumber(owned.Pokemon), owned.Level, owned.Experience)
+	fmt.Printf("Name: %s\nHeight: %v\nWeight: %v\n", pokemon.Name, pokemon.Height, pokemon.Weight)
+	fmt.Printf("Gender: %s\nNature: %s\n", owned.Gender, owned.Nature)
+	fmt.Printf("Happiness: %d\n", owned.Happiness)
+	fmt.Printf("HP: %d/%d\n", currentHP(owned), owned.Stats["hp"])
+	if owned.HeldItem != "" {
+		fmt.Printf("Held item: %s\n", owned.HeldItem)
+	}
+	if owned.Shiny {
+		fmt.Println("Shiny: yes")
+	}
+
+	fmt.Printf("Stats:          base  IV  EV  actual\n")
+	for _, s := range owned.Pokemon.Stats {
+		name := s.Stat.Name
+		fmt.Printf(" - %-15s %3d  %2d  %3d  %4d\n", name+":", s.BaseStat, owned.IVs[name], owned.EVs[name], owned.Stats[name])
+	}
+
+	gen := activeGeneration(config)
+	fmt.Printf("Types (generation %d):\n", gen)
+	for _, t := range typesForGeneration(owned.Pokemon, gen) {
+		fmt.Printf(" - %s\n", t)
+	}
+	if abilities := abilitiesForGeneration(pokemon, gen); len(abilities) > 0 {
+		fmt.Printf("Abilities:\n")
+		for _, a := range abilities {
+			if a.IsHidden {
+				fmt.Printf(" - %s (hidden)\n", a.Ability.Name)
+				continue
+			}
+			fmt.Printf(" - %s\n", a.Ability.Name)
+		}
+	}
+	fmt.Printf("Moves:\n")
+	for _, m := range owned.Moves {
+		fmt.Printf(" - %s\n", m)
 	}
 
 	return nil
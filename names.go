@@ -0,0 +1,299 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	nameIndexFileName = "names.json"
+	// nameIndexTTL is how long the name index is used before it is rebuilt
+	nameIndexTTL = 7 * 24 * time.Hour
+	// nameIndexPageSize is how many names are fetched per request when
+	// building the index
+	nameIndexPageSize = 1000
+	// maxSuggestions is how many "did you mean" names are offered
+	maxSuggestions = 3
+	// maxSearchResults is how many matches search shows per kind of resource
+	maxSearchResults = 10
+)
+
+// nameIndexEndpoints are the list endpoints whose names are indexed, in
+// the order search shows them
+var nameIndexEndpoints = []string{"pokemon", "location-area", "move", "item"}
+
+// nameIndex holds the names of every resource of the indexed endpoints
+type nameIndex struct {
+	Built time.Time           `json:"built"`
+	Names map[string][]string `json:"names"`
+}
+
+// loadedNames is the name index, loaded the first time it is needed
+var loadedNames *nameIndex
+
+// nameIndexPath returns the location of the cached name index
+func nameIndexPath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, nameIndexFileName), nil
+}
+
+// complete reports whether the index is recent and covers every endpoint
+func (idx *nameIndex) complete() bool {
+	if time.Since(idx.Built) > nameIndexTTL {
+		return false
+	}
+	for _, endpoint := range nameIndexEndpoints {
+		if len(idx.Names[endpoint]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// buildNameIndex fetches the names of every resource of the indexed endpoints
+func buildNameIndex(client *pokeapi.Client) (*nameIndex, error) {
+	idx := &nameIndex{Built: time.Now(), Names: make(map[string][]string)}
+	for _, endpoint := range nameIndexEndpoints {
+		for resource, err := range client.Resources(endpoint, pokeapi.ListOptions{PageSize: nameIndexPageSize}) {
+			if err != nil {
+				return nil, err
+			}
+			idx.Names[endpoint] = append(idx.Names[endpoint], resource.Name)
+		}
+	}
+	return idx, nil
+}
+
+// readNameIndex returns the name index already loaded or cached on disk,
+// nil if there is none yet or it is stale
+func readNameIndex() *nameIndex {
+	if loadedNames != nil {
+		return loadedNames
+	}
+
+	path, err := nameIndexPath()
+	if err != nil {
+		return nil
+	}
+	var idx nameIndex
+	if err := readJSONFile(path, &idx); err != nil || !idx.complete() {
+		return nil
+	}
+
+	loadedNames = &idx
+	return loadedNames
+}
+
+// loadNameIndex returns the name index, read from disk or built from the
+// API and cached on disk when missing or stale
+func loadNameIndex(client *pokeapi.Client) (*nameIndex, error) {
+	if idx := readNameIndex(); idx != nil {
+		return idx, nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Indexing Pokemon, areas, moves and items...")
+	idx, err := buildNameIndex(client)
+	if err != nil {
+		return nil, err
+	}
+	path, err := nameIndexPath()
+	if err == nil {
+		err = writeJSONFile(path, idx)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error caching name index:", err)
+	}
+
+	loadedNames = idx
+	return loadedNames, nil
+}
+
+// editDistance returns the Damerau-Levenshtein distance between a and b
+// (optimal string alignment): the number of insertions, deletions,
+// substitutions and swaps of adjacent letters turning one into the other
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between the first i runes of s and the first j of t
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+// suggestNames returns the candidates closest to query, at most
+// maxSuggestions of them, allowing about one typo per three letters
+func suggestNames(candidates []string, query string) []string {
+	maxDistance := max(1, min(3, len([]rune(query))/3))
+
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, name := range candidates {
+		if d := editDistance(query, name); d <= maxDistance {
+			suggestions = append(suggestions, suggestion{name, d})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	closest := make([]string, 0, maxSuggestions)
+	for _, s := range suggestions[:min(len(suggestions), maxSuggestions)] {
+		closest = append(closest, s.name)
+	}
+	return closest
+}
+
+// searchNames returns the candidates starting with query, then the ones
+// containing it elsewhere, each group sorted by name
+func searchNames(candidates []string, query string) []string {
+	var prefixed, containing []string
+	for _, name := range candidates {
+		switch {
+		case strings.HasPrefix(name, query):
+			prefixed = append(prefixed, name)
+		case strings.Contains(name, query):
+			containing = append(containing, name)
+		}
+	}
+	sort.Strings(prefixed)
+	sort.Strings(containing)
+	return append(prefixed, containing...)
+}
+
+// endpointLabel turns an endpoint into words, e.g. "location area"
+func endpointLabel(endpoint string) string {
+	return strings.ReplaceAll(endpoint, "-", " ")
+}
+
+// withSuggestions turns a not-found error for the resource name of
+// endpoint into one suggesting the closest known names. Other errors, or
+// a name index that can't be loaded, leave err as is.
+func withSuggestions(client *pokeapi.Client, endpoint, name string, err error) error {
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return err
+	}
+
+	idx, indexErr := loadNameIndex(client)
+	if indexErr != nil {
+		return err
+	}
+
+	return notFoundError(idx, endpoint, name)
+}
+
+// notFoundError reports that there is no resource name of endpoint,
+// suggesting the closest names of the index
+func notFoundError(idx *nameIndex, endpoint, name string) error {
+	notFound := fmt.Errorf("there is no %s named %s", endpointLabel(endpoint), name)
+	suggestions := suggestNames(idx.Names[endpoint], name)
+	if len(suggestions) == 0 {
+		return notFound
+	}
+	return fmt.Errorf("%w. Did you mean %s?", notFound, strings.Join(suggestions, ", "))
+}
+
+// suggestAmong adds suggestions to err, the error of a name not among
+// candidates, such as an item not in the bag or a move not known: the
+// candidates close to name or, when name is no resource of endpoint at
+// all, the closest names of the index. A name index that can't be loaded,
+// or doesn't hold endpoint, leaves err as is.
+func suggestAmong(client *pokeapi.Client, endpoint, name string, candidates []string, err error) error {
+	if suggestions := suggestNames(candidates, name); len(suggestions) > 0 {
+		return fmt.Errorf("%w. Did you mean %s?", err, strings.Join(suggestions, ", "))
+	}
+	if !slices.Contains(nameIndexEndpoints, endpoint) {
+		return err
+	}
+
+	idx, indexErr := loadNameIndex(client)
+	if indexErr != nil || slices.Contains(idx.Names[endpoint], name) {
+		return err
+	}
+	return notFoundError(idx, endpoint, name)
+}
+
+// missingItemError reports that there is no itemName in the bag, with
+// suggestions
+func missingItemError(client *pokeapi.Client, config *pokeapi.Config, itemName string) error {
+	err := fmt.Errorf("you don't have any %s", itemName)
+	return suggestAmong(client, "item", itemName, inventoryItemNames(config), err)
+}
+
+// commandSearch lists the Pokemon, location areas, moves and items whose
+// name starts with or contains some text. Usage: search <text>
+func commandSearch(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	query := strings.Join(args, "-")
+
+	idx, err := loadNameIndex(client)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, endpoint := range nameIndexEndpoints {
+		matches := searchNames(idx.Names[endpoint], query)
+		if len(matches) == 0 {
+			continue
+		}
+		found = true
+
+		fmt.Printf("%s (%d):\n", endpointLabel(endpoint), len(matches))
+		for _, name := range matches[:min(len(matches), maxSearchResults)] {
+			fmt.Printf(" - %s\n", name)
+		}
+		if len(matches) > maxSearchResults {
+			fmt.Printf("   ... and %d more\n", len(matches)-maxSearchResults)
+		}
+	}
+
+	if !found {
+		var suggestions []string
+		for _, endpoint := range nameIndexEndpoints {
+			suggestions = append(suggestions, suggestNames(idx.Names[endpoint], query)...)
+		}
+		if len(suggestions) == 0 {
+			fmt.Printf("Nothing matches %s.\n", query)
+			return nil
+		}
+		fmt.Printf("Nothing matches %s. Did you mean %s?\n", query, strings.Join(suggestions, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		distance int
+	}{
+		{"charmander", "charmander", 0},
+		{"charmandr", "charmander", 1},
+		{"chramander", "charmander", 1},
+		{"pikachu", "raichu", 4},
+		{"", "eevee", 5},
+	}
+	for _, c := range cases {
+		if actual := editDistance(c.a, c.b); actual != c.distance {
+			t.Errorf("%s/%s: expected distance %d, got %d", c.a, c.b, c.distance, actual)
+		}
+	}
+}
+
+func TestSuggestAndSearchNames(t *testing.T) {
+	candidates := []string{"charmander", "charmeleon", "charizard", "pikachu", "mr-mime", "mime-jr"}
+
+	if actual := suggestNames(candidates, "charmandr"); !slices.Equal(actual, []string{"charmander"}) {
+		t.Errorf("unexpected suggestions %v", actual)
+	}
+	if actual := suggestNames(candidates, "bulbasaur"); len(actual) != 0 {
+		t.Errorf("expected no suggestions, got %v", actual)
+	}
+
+	if actual := searchNames(candidates, "char"); !slices.Equal(actual, []string{"charizard", "charmander", "charmeleon"}) {
+		t.Errorf("unexpected search results %v", actual)
+	}
+	// Prefix matches come before the names containing the text
+	if actual := searchNames(candidates, "mime"); !slices.Equal(actual, []string{"mime-jr", "mr-mime"}) {
+		t.Errorf("unexpected search results %v", actual)
+	}
+}
+
+func TestNameIndexSuggestions(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	loadedNames = nil
+	t.Cleanup(func() { loadedNames = nil })
+
+	client := newTestClient(t, map[string]string{
+		"/pokemon":       `{"count": 2, "results": [{"name": "charmander"}, {"name": "charmeleon"}]}`,
+		"/location-area": `{"count": 1, "results": [{"name": "viridian-forest-area"}]}`,
+		"/move":          `{"count": 1, "results": [{"name": "ember"}]}`,
+		"/item":          `{"count": 1, "results": [{"name": "fire-stone"}]}`,
+	})
+
+	_, err := fetchPokemon(client, "charmandr")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean charmander?") {
+		t.Fatalf("expected a suggestion, got %v", err)
+	}
+
+	// The index is cached on disk and reused by the next session
+	path, err := nameIndexPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the index to be cached in %s: %v", filepath.Dir(path), err)
+	}
+	loadedNames = nil
+	idx, err := loadNameIndex(pokeapi.NewClient())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(idx.Names["move"], []string{"ember"}) {
+		t.Errorf("expected the cached moves, got %v", idx.Names["move"])
+	}
+
+	other := errors.New("connection refused")
+	if err := withSuggestions(client, "pokemon", "charmandr", other); err != other {
+		t.Errorf("expected other errors to be left as is, got %v", err)
+	}
+	if err := commandSearch(client, &pokeapi.Config{}, []string{"fire"}, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSuggestAmong(t *testing.T) {
+	loadedNames = &nameIndex{Built: time.Now(), Names: map[string][]string{
+		"item": {"great-ball", "potion", "super-potion"},
+		"move": {"ember", "tackle"},
+	}}
+	t.Cleanup(func() { loadedNames = nil })
+	client := newTestClient(t, map[string]string{})
+
+	config := &pokeapi.Config{Inventory: map[string]int{"potion": 2}}
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		// A close name at hand comes first
+		{"potoin", missingItemError(client, config, "potoin"), "you don't have any potoin. Did you mean potion?"},
+		// A real item that isn't at hand needs no suggestion
+		{"great-ball", missingItemError(client, config, "great-ball"), "you don't have any great-ball"},
+		// A misspelled item gets the closest items of the game
+		{"grate-ball", missingItemError(client, config, "grate-ball"), "there is no item named grate-ball. Did you mean great-ball?"},
+		{"tackel", suggestAmong(client, "move", "tackel", []string{"ember"}, errors.New("pikachu doesn't know tackel")), "there is no move named tackel. Did you mean tackle?"},
+	}
+	for _, c := range cases {
+		if c.err == nil || c.err.Error() != c.expected {
+			t.Errorf("%s: expected %q, got %v", c.name, c.expected, c.err)
+		}
+	}
+
+	if _, err := itemPrice(client, "grate-ball"); err == nil || !strings.Contains(err.Error(), "Did you mean great-ball?") {
+		t.Errorf("expected the shop to suggest great-ball, got %v", err)
+	}
+}
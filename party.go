@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// partySize is how many Pokemon the player carries and takes into battle
+	partySize = 6
+	// boxSize is how many Pokemon a PC box holds
+	boxSize = 30
+	// maxNicknameLength is the longest nickname a Pokemon can be given
+	maxNicknameLength = 12
+)
+
+// partyPokemon returns the Pokemon of the party, in battle order
+func partyPokemon(config *pokeapi.Config) []*pokeapi.OwnedPokemon {
+	party := make([]*pokeapi.OwnedPokemon, 0, len(config.Party))
+	for _, id := range config.Party {
+		if owned, exists := config.CaughtPokemon[id]; exists {
+			party = append(party, owned)
+		}
+	}
+	return party
+}
+
+// storePokemon sends the Pokemon with id to the party, or to the first PC
+// box with room when the party is full. It returns the box number, 0 for
+// the party.
+func storePokemon(config *pokeapi.Config, id int) int {
+	if len(config.Party) < partySize {
+		config.Party = append(config.Party, id)
+		return 0
+	}
+	return depositInBox(config, id)
+}
+
+// depositInBox puts the Pokemon with id in the first PC box with room,
+// opening a new box when they are all full. It returns the box number.
+func depositInBox(config *pokeapi.Config, id int) int {
+	for i, box := range config.Boxes {
+		if len(box) < boxSize {
+			config.Boxes[i] = append(box, id)
+			return i + 1
+		}
+	}
+
+	config.Boxes = append(config.Boxes, []int{id})
+	return len(config.Boxes)
+}
+
+// findSlot returns where the Pokemon with id is stored: its box number (0
+// for the party) and its position in it
+func findSlot(config *pokeapi.Config, id int) (box, index int, ok bool) {
+	if i := slices.Index(config.Party, id); i >= 0 {
+		return 0, i, true
+	}
+	for b, ids := range config.Boxes {
+		if i := slices.Index(ids, id); i >= 0 {
+			return b + 1, i, true
+		}
+	}
+	return 0, 0, false
+}
+
+// slotIDs returns the IDs stored in box, 0 being the party
+func slotIDs(config *pokeapi.Config, box int) *[]int {
+	if box == 0 {
+		return &config.Party
+	}
+	return &config.Boxes[box-1]
+}
+
+// unstorePokemon takes the Pokemon with id out of the party or its PC box
+func unstorePokemon(config *pokeapi.Config, id int) {
+	box, index, ok := findSlot(config, id)
+	if !ok {
+		return
+	}
+	ids := slotIDs(config, box)
+	*ids = slices.Delete(*ids, index, index+1)
+}
+
+// printPokemonList prints ids as a numbered list of Pokemon
+func printPokemonList(config *pokeapi.Config, ids []int) {
+	for i, id := range ids {
+		owned := config.CaughtPokemon[id]
+		fmt.Printf(" %d. %s (ID %d, Lv. %d, HP %d/%d)\n", i+1, owned.Name(), owned.ID, owned.Level, currentHP(owned), owned.Stats["hp"])
+	}
+}
+
+// commandParty lists the Pokemon of the party in battle order
+func commandParty(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(config.Party) == 0 {
+		fmt.Println("Your party is empty. Catch a Pokemon first.")
+		return nil
+	}
+
+	fmt.Printf("Your party (%d/%d):\n", len(config.Party), partySize)
+	printPokemonList(config, config.Party)
+
+	return nil
+}
+
+// commandBox lists the Pokemon stored in a PC box. Usage: box [n]
+func commandBox(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	n := 1
+	if len(args) == 1 {
+		var err error
+		n, err = strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("box must be a positive number. Usage: %s", commandLists["box"].usageLine())
+		}
+	}
+
+	if n > len(config.Boxes) || len(config.Boxes[n-1]) == 0 {
+		fmt.Printf("Box %d is empty.\n", n)
+		return nil
+	}
+
+	fmt.Printf("Box %d (%d/%d):\n", n, len(config.Boxes[n-1]), boxSize)
+	printPokemonList(config, config.Boxes[n-1])
+	if len(config.Boxes) > 1 {
+		fmt.Printf("You have %d boxes.\n", len(config.Boxes))
+	}
+
+	return nil
+}
+
+// commandDeposit moves a Pokemon from the party to the PC. Usage: deposit <id>
+func commandDeposit(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	if box, _, _ := findSlot(config, owned.ID); box != 0 {
+		return fmt.Errorf("%s is already in box %d", owned.Name(), box)
+	}
+	if len(config.Party) == 1 {
+		return fmt.Errorf("%s is your last party Pokemon", owned.Name())
+	}
+
+	unstorePokemon(config, owned.ID)
+	box := depositInBox(config, owned.ID)
+	fmt.Printf("%s was deposited in box %d.\n", owned.Name(), box)
+
+	return nil
+}
+
+// commandWithdraw moves a Pokemon from the PC to the party. Usage: withdraw <id>
+func commandWithdraw(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	if box, _, _ := findSlot(config, owned.ID); box == 0 {
+		return fmt.Errorf("%s is already in your party", owned.Name())
+	}
+	if len(config.Party) >= partySize {
+		return fmt.Errorf("your party is full. Deposit a Pokemon first with: deposit <id>")
+	}
+
+	unstorePokemon(config, owned.ID)
+	config.Party = append(config.Party, owned.ID)
+	fmt.Printf("%s joined your party.\n", owned.Name())
+
+	return nil
+}
+
+// commandSwap swaps the places of two Pokemon, e.g. to change the lead of
+// the party or to trade a party Pokemon for a boxed one. Usage: swap <id> <id>
+func commandSwap(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	a, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+	b, err := parseOwnedID(config, args[1])
+	if err != nil {
+		return err
+	}
+
+	boxA, indexA, _ := findSlot(config, a.ID)
+	boxB, indexB, _ := findSlot(config, b.ID)
+	idsA, idsB := slotIDs(config, boxA), slotIDs(config, boxB)
+	(*idsA)[indexA], (*idsB)[indexB] = b.ID, a.ID
+	fmt.Printf("%s and %s swapped places.\n", a.Name(), b.Name())
+
+	return nil
+}
+
+// commandRelease lets a Pokemon go for good, after asking the player. The
+// item it held goes back to the bag. Usage: release <id>
+func commandRelease(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	if box, _, _ := findSlot(config, owned.ID); box == 0 && len(config.Party) == 1 {
+		return fmt.Errorf("%s is your last party Pokemon", owned.Name())
+	}
+	if !confirm(fmt.Sprintf("Release %s (Lv. %d)? You will never see it again.", owned.Name(), owned.Level)) {
+		fmt.Println("Release cancelled.")
+		return nil
+	}
+
+	unstorePokemon(config, owned.ID)
+	delete(config.CaughtPokemon, owned.ID)
+	if owned.HeldItem != "" {
+		addItem(config, owned.HeldItem, 1)
+	}
+	fmt.Printf("%s was released. Bye-bye, %s!\n", owned.Name(), owned.Name())
+
+	return nil
+}
+
+// commandNickname gives a Pokemon a nickname, or removes it when no name
+// is given. Usage: nickname <id> [name]
+func commandNickname(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	owned, err := parseOwnedID(config, args[0])
+	if err != nil {
+		return err
+	}
+
+	name := strings.Join(args[1:], " ")
+	if name == "" {
+		owned.Nickname = ""
+		fmt.Printf("%s no longer has a nickname.\n", owned.Name())
+		return nil
+	}
+	if utf8.RuneCountInString(name) > maxNicknameLength {
+		return fmt.Errorf("nicknames are at most %d characters long", maxNicknameLength)
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("a nickname can't be a number, it would be mistaken for an ID")
+	}
+
+	fmt.Printf("%s is now called %s.\n", owned.Name(), name)
+	owned.Nickname = name
+
+	return nil
+}
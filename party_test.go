@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// newTestParty catches n pikachu, filling the party then the PC boxes
+func newTestParty(n int) *pokeapi.Config {
+	config := &pokeapi.Config{}
+	for i := 0; i < n; i++ {
+		addOwnedPokemon(config, &pokeapi.OwnedPokemon{Level: 5, Pokemon: pokeapi.PokemonSummary{Name: "pikachu"}})
+	}
+	return config
+}
+
+func TestStorePokemon(t *testing.T) {
+	config := newTestParty(partySize + boxSize + 1)
+
+	if !slices.Equal(config.Party, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("expected the first six Pokemon in the party, got %v", config.Party)
+	}
+	if len(config.Boxes) != 2 || len(config.Boxes[0]) != boxSize || config.Boxes[1][0] != partySize+boxSize+1 {
+		t.Errorf("expected a full first box and the last Pokemon in box 2, got %d boxes", len(config.Boxes))
+	}
+	if box, index, ok := findSlot(config, 8); !ok || box != 1 || index != 1 {
+		t.Errorf("expected #8 in box 1 at position 1, got box %d position %d (%v)", box, index, ok)
+	}
+}
+
+func TestDepositWithdrawSwap(t *testing.T) {
+	config := newTestParty(partySize + 1)
+
+	if err := commandWithdraw(nil, config, []string{"7"}, nil); err == nil {
+		t.Error("expected an error withdrawing into a full party")
+	}
+	if err := commandDeposit(nil, config, []string{"2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandWithdraw(nil, config, []string{"7"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(config.Party, []int{1, 3, 4, 5, 6, 7}) || !slices.Equal(config.Boxes[0], []int{2}) {
+		t.Errorf("unexpected party %v and box %v", config.Party, config.Boxes[0])
+	}
+
+	// Swapping a boxed Pokemon with the lead changes the battle order
+	if err := commandSwap(nil, config, []string{"2", "1"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if partyPokemon(config)[0].ID != 2 || config.Boxes[0][0] != 1 {
+		t.Errorf("expected #2 to lead and #1 to be boxed, got party %v and box %v", config.Party, config.Boxes[0])
+	}
+}
+
+func TestDepositLastPartyPokemon(t *testing.T) {
+	config := newTestParty(1)
+	if err := commandDeposit(nil, config, []string{"1"}, nil); err == nil {
+		t.Error("expected an error depositing the last party Pokemon")
+	}
+}
+
+func TestRelease(t *testing.T) {
+	original := confirm
+	t.Cleanup(func() { confirm = original })
+
+	config := newTestParty(2)
+	config.CaughtPokemon[2].HeldItem = "everstone"
+
+	confirm = func(string) bool { return false }
+	if err := commandRelease(nil, config, []string{"2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.CaughtPokemon) != 2 {
+		t.Fatal("expected the release to be cancelled")
+	}
+
+	confirm = func(string) bool { return true }
+	if err := commandRelease(nil, config, []string{"2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := config.CaughtPokemon[2]; exists || !slices.Equal(config.Party, []int{1}) {
+		t.Errorf("expected #2 to be released, got party %v", config.Party)
+	}
+	if config.Inventory["everstone"] != 1 {
+		t.Error("expected the held item to go back to the bag")
+	}
+}
+
+func TestLoadSaveWithoutParty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	save := `{"pokemon": {
+		"1": {"id": 1, "level": 5, "pokemon": {"name": "pidgey"}},
+		"2": {"id": 2, "level": 5, "pokemon": {"name": "rattata"}}
+	}, "next_pokemon_id": 2}`
+	if err := os.WriteFile(path, []byte(save), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &pokeapi.Config{}
+	if err := loadGame(nil, path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(config.Party, []int{1, 2}) {
+		t.Errorf("expected both Pokemon to join the party, got %v", config.Party)
+	}
+}
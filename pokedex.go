@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// firstFormID is where PokeAPI starts numbering alternate forms, whose
+	// IDs are not national dex numbers
+	firstFormID = 10001
+	// maxBlankSlots is how many unseen slots in a row are shown before they
+	// are folded into one line
+	maxBlankSlots = 3
+)
+
+// lastDexNumbers holds the last national dex number introduced by each generation
+var lastDexNumbers = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// nationalDexSize is the number of species in the national dex
+var nationalDexSize = lastDexNumbers[len(lastDexNumbers)-1]
+
+// nationalNumber returns the national dex number of the species of pokemon
+func nationalNumber(pokemon pokeapi.PokemonSummary) int {
+	if n := pokemon.Species.ID(); n > 0 {
+		return n
+	}
+	return pokemon.ID
+}
+
+// registerSeen records that the species numbered number has been met
+func registerSeen(config *pokeapi.Config, number int, name string) {
+	if number <= 0 || number >= firstFormID {
+		return
+	}
+	if config.Seen == nil {
+		config.Seen = make(map[int]string)
+	}
+	if _, seen := config.Seen[number]; !seen {
+		config.Seen[number] = name
+	}
+}
+
+// registerSeenPokemon records that the species of pokemon has been met
+func registerSeenPokemon(config *pokeapi.Config, pokemon pokeapi.PokemonSummary) {
+	name := pokemon.Species.Name
+	if name == "" {
+		name = pokemon.Name
+	}
+	registerSeen(config, nationalNumber(pokemon), name)
+}
+
+// registerCaught records that the species of pokemon has been seen and caught
+func registerCaught(config *pokeapi.Config, pokemon pokeapi.PokemonSummary) {
+	registerSeenPokemon(config, pokemon)
+
+	number := nationalNumber(pokemon)
+	if _, seen := config.Seen[number]; !seen {
+		return
+	}
+	if config.Caught == nil {
+		config.Caught = make(map[int]bool)
+	}
+	config.Caught[number] = true
+}
+
+// generationOf returns the generation that introduced the species numbered number
+func generationOf(number int) int {
+	for i, last := range lastDexNumbers {
+		if number <= last {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// completion formats how many of total species were caught, e.g. "12/151 (7.9%)"
+func completion(caught, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%.1f%%)", caught, total, float64(caught)*100/float64(total))
+}
+
+// caughtInPokedex counts the species of pokedex that were caught
+func caughtInPokedex(config *pokeapi.Config, pokedex *pokeapi.Pokedex) int {
+	caught := 0
+	for _, entry := range pokedex.PokemonEntries {
+		if config.Caught[entry.PokemonSpecies.ID()] {
+			caught++
+		}
+	}
+	return caught
+}
+
+// dexSlot is one numbered entry of a Pokedex view
+type dexSlot struct {
+	number int
+	name   string
+	seen   bool
+	caught bool
+}
+
+// nationalSlots returns the national dex slots up to the highest seen species
+func nationalSlots(config *pokeapi.Config) []dexSlot {
+	highest := 0
+	for number := range config.Seen {
+		highest = max(highest, number)
+	}
+
+	slots := make([]dexSlot, 0, highest)
+	for number := 1; number <= highest; number++ {
+		name, seen := config.Seen[number]
+		slots = append(slots, dexSlot{number: number, name: name, seen: seen, caught: config.Caught[number]})
+	}
+	return slots
+}
+
+// regionalSlots returns the slots of pokedex, numbered with its own entry numbers
+func regionalSlots(config *pokeapi.Config, pokedex *pokeapi.Pokedex) []dexSlot {
+	slots := make([]dexSlot, 0, len(pokedex.PokemonEntries))
+	for _, entry := range pokedex.PokemonEntries {
+		national := entry.PokemonSpecies.ID()
+		_, seen := config.Seen[national]
+		slots = append(slots, dexSlot{
+			number: entry.EntryNumber,
+			name:   entry.PokemonSpecies.Name,
+			seen:   seen,
+			caught: config.Caught[national],
+		})
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].number < slots[j].number
+	})
+	return slots
+}
+
+// printDexSlots lists slots, hiding the names of unseen species and folding
+// long runs of them
+func printDexSlots(slots []dexSlot) {
+	var blanks []dexSlot
+	flushBlanks := func() {
+		if len(blanks) > maxBlankSlots {
+			fmt.Printf("   ... %d unseen\n", len(blanks))
+		} else {
+			for _, slot := range blanks {
+				fmt.Printf(" #%04d ---\n", slot.number)
+			}
+		}
+		blanks = blanks[:0]
+	}
+
+	for _, slot := range slots {
+		if !slot.seen {
+			blanks = append(blanks, slot)
+			continue
+		}
+		flushBlanks()
+
+		status := "seen"
+		if slot.caught {
+			status = "caught"
+		}
+		fmt.Printf(" #%04d %-12s %s\n", slot.number, slot.name, status)
+	}
+	flushBlanks()
+}
+
+// printCompletion prints the completion of the national dex, of each
+// generation and of each main-series regional dex
+func printCompletion(client *pokeapi.Client, config *pokeapi.Config) error {
+	fmt.Printf("National: %s\n", completion(len(config.Caught), nationalDexSize))
+
+	caughtPerGen := make(map[int]int)
+	for number := range config.Caught {
+		caughtPerGen[generationOf(number)]++
+	}
+	first := 1
+	for i, last := range lastDexNumbers {
+		fmt.Printf(" Generation %d: %s\n", i+1, completion(caughtPerGen[i+1], last-first+1))
+		first = last + 1
+	}
+
+	var names []string
+	for resource, err := range client.Resources("pokedex", pokeapi.ListOptions{}) {
+		if err != nil {
+			return err
+		}
+		names = append(names, resource.Name)
+	}
+
+	fmt.Println("Regional Pokedexes:")
+	for _, result := range client.GetPokedexBatch(names, batchOptions("Fetching Pokedexes", len(names))) {
+		if result.Err != nil {
+			return result.Err
+		}
+		pokedex := result.Value
+		if !pokedex.IsMainSeries || pokedex.Region == nil {
+			continue
+		}
+
+		fmt.Printf(" %s: %s\n", pokedex.Name, completion(caughtInPokedex(config, pokedex), len(pokedex.PokemonEntries)))
+	}
+
+	return nil
+}
+
+// printRegionalPokedex lists the species of a Pokedex of region with their
+// regional numbers. dex picks the Pokedex by name or by number in the
+// region's list, the first one when empty; the others are listed after.
+func printRegionalPokedex(client *pokeapi.Client, config *pokeapi.Config, regionName, dex string) error {
+	region, err := client.GetRegion(regionName)
+	if err != nil {
+		return err
+	}
+	if len(region.Pokedexes) == 0 {
+		return fmt.Errorf("the %s region has no Pokedex", region.Name)
+	}
+
+	index := 0
+	if dex != "" {
+		index = slices.IndexFunc(region.Pokedexes, func(p pokeapi.NamedAPIResource) bool {
+			return p.Name == dex
+		})
+		if n, err := strconv.Atoi(dex); err == nil && n >= 1 && n <= len(region.Pokedexes) {
+			index = n - 1
+		}
+		if index < 0 {
+			return fmt.Errorf("the %s region has no Pokedex %s. Its Pokedexes are: %s", region.Name, dex, resourceNames(region.Pokedexes))
+		}
+	}
+
+	pokedex, err := client.GetPokedex(region.Pokedexes[index].Name)
+	if err != nil {
+		return err
+	}
+
+	slots := regionalSlots(config, pokedex)
+	seen := 0
+	for _, slot := range slots {
+		if slot.seen {
+			seen++
+		}
+	}
+
+	fmt.Printf("%s Pokedex:\n", pokedex.Name)
+	printDexSlots(slots)
+	fmt.Printf("Seen: %d, caught: %s\n", seen, completion(caughtInPokedex(config, pokedex), len(slots)))
+
+	if len(region.Pokedexes) > 1 {
+		fmt.Printf("The %s region has %d Pokedexes:\n", region.Name, len(region.Pokedexes))
+		for i, p := range region.Pokedexes {
+			fmt.Printf(" %d. %s\n", i+1, p.Name)
+		}
+		fmt.Printf("Show another with: pokedex --region %s --dex <name|number>\n", region.Name)
+	}
+
+	return nil
+}
+
+// commandPokedex lists the species seen and caught in national dex order,
+// in the regional order of a region, or their completion.
+// Usage: pokedex [completion] [--region <region>]
+func commandPokedex(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	region, byRegion := flags["region"]
+	dex, byDex := flags["dex"]
+	switch {
+	case byDex && !byRegion:
+		return fmt.Errorf("--dex picks a Pokedex of the region given with --region. Usage: %s", commandLists["pokedex"].usageLine())
+	case len(args) == 1 && args[0] != "completion":
+		return fmt.Errorf("unknown option %q. Usage: %s", args[0], commandLists["pokedex"].usageLine())
+	case len(args) == 1 && byRegion:
+		return fmt.Errorf("completion and --region can't be used together")
+	case len(args) == 1:
+		return printCompletion(client, config)
+	case byRegion:
+		return printRegionalPokedex(client, config, region, dex)
+	}
+
+	if len(config.Seen) == 0 {
+		fmt.Println("Your Pokedex is empty. Go meet some Pokemon!")
+		return nil
+	}
+
+	fmt.Println("Your Pokedex:")
+	printDexSlots(nationalSlots(config))
+	fmt.Printf("Seen: %d, caught: %s\n", len(config.Seen), completion(len(config.Caught), nationalDexSize))
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestRegisterSeenAndCaught(t *testing.T) {
+	config := &pokeapi.Config{}
+
+	registerSeen(config, 16, "pidgey")
+	registerSeen(config, 10033, "venusaur-mega")
+
+	pikachu := &pokeapi.Pokemon{
+		ID:      25,
+		Name:    "pikachu",
+		Species: pokeapi.NamedAPIResource{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+	}
+	registerCaught(config, pikachu.Summary())
+
+	// Alternate forms are registered under their species
+	alolan := &pokeapi.Pokemon{
+		ID:      10100,
+		Name:    "raichu-alola",
+		Species: pokeapi.NamedAPIResource{Name: "raichu", URL: "https://pokeapi.co/api/v2/pokemon-species/26/"},
+	}
+	registerCaught(config, alolan.Summary())
+
+	if len(config.Seen) != 3 || config.Seen[26] != "raichu" {
+		t.Errorf("expected pidgey, pikachu and raichu to be seen, got %v", config.Seen)
+	}
+	if config.Caught[16] || !config.Caught[25] || !config.Caught[26] {
+		t.Errorf("expected pikachu and raichu to be caught, got %v", config.Caught)
+	}
+}
+
+func TestGenerationOf(t *testing.T) {
+	cases := map[int]int{1: 1, 151: 1, 152: 2, 493: 4, 1025: 9, 1026: 0}
+	for number, gen := range cases {
+		if actual := generationOf(number); actual != gen {
+			t.Errorf("#%d: expected generation %d, got %d", number, gen, actual)
+		}
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	if actual := completion(15, 151); actual != "15/151 (9.9%)" {
+		t.Errorf("unexpected completion %q", actual)
+	}
+}
+
+func TestPrintCompletion(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/pokedex":          `{"results": [{"name": "national"}, {"name": "kanto"}, {"name": "conquest-gallery"}]}`,
+		"/pokedex/national": `{"name": "national", "is_main_series": true, "pokemon_entries": []}`,
+		"/pokedex/kanto": `{"name": "kanto", "is_main_series": true, "region": {"name": "kanto"}, "pokemon_entries": [
+			{"entry_number": 1, "pokemon_species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"}},
+			{"entry_number": 2, "pokemon_species": {"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon-species/2/"}}
+		]}`,
+		"/pokedex/conquest-gallery": `{"name": "conquest-gallery", "is_main_series": false, "pokemon_entries": []}`,
+	})
+
+	config := &pokeapi.Config{Caught: map[int]bool{1: true, 152: true}}
+	if err := printCompletion(client, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kanto, err := client.GetPokedex("kanto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caught := caughtInPokedex(config, kanto); caught != 1 {
+		t.Errorf("expected 1 of the kanto Pokemon caught, got %d", caught)
+	}
+}
+
+func TestRegionalSlots(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/region/johto": `{"name": "johto", "pokedexes": [{"name": "original-johto"}]}`,
+		"/pokedex/original-johto": `{"name": "original-johto", "pokemon_entries": [
+			{"entry_number": 2, "pokemon_species": {"name": "bayleef", "url": "https://pokeapi.co/api/v2/pokemon-species/153/"}},
+			{"entry_number": 1, "pokemon_species": {"name": "chikorita", "url": "https://pokeapi.co/api/v2/pokemon-species/152/"}},
+			{"entry_number": 22, "pokemon_species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"}}
+		]}`,
+	})
+
+	config := &pokeapi.Config{
+		Seen:   map[int]string{25: "pikachu", 152: "chikorita"},
+		Caught: map[int]bool{25: true},
+	}
+
+	pokedex, err := client.GetPokedex("original-johto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []dexSlot{
+		{number: 1, name: "chikorita", seen: true},
+		{number: 2, name: "bayleef"},
+		{number: 22, name: "pikachu", seen: true, caught: true},
+	}
+	slots := regionalSlots(config, pokedex)
+	if len(slots) != len(expected) {
+		t.Fatalf("expected %d slots, got %d", len(expected), len(slots))
+	}
+	for i := range expected {
+		if slots[i] != expected[i] {
+			t.Errorf("slot %d: expected %+v, got %+v", i, expected[i], slots[i])
+		}
+	}
+
+	if err := commandPokedex(client, config, nil, map[string]string{"region": "johto"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegionalPokedexChoice(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/region/kalos": `{"name": "kalos", "pokedexes": [{"name": "kalos-central"}, {"name": "kalos-coastal"}, {"name": "kalos-mountain"}]}`,
+		"/pokedex/kalos-coastal": `{"name": "kalos-coastal", "pokemon_entries": [
+			{"entry_number": 1, "pokemon_species": {"name": "chespin", "url": "https://pokeapi.co/api/v2/pokemon-species/650/"}}
+		]}`,
+	})
+	config := &pokeapi.Config{}
+
+	// kalos-central isn't served, so only the coastal dex can be shown
+	for _, dex := range []string{"kalos-coastal", "2"} {
+		if err := printRegionalPokedex(client, config, "kalos", dex); err != nil {
+			t.Errorf("%s: unexpected error: %v", dex, err)
+		}
+	}
+	for _, dex := range []string{"", "kalos-mountain", "4", "johto"} {
+		if err := printRegionalPokedex(client, config, "kalos", dex); err == nil {
+			t.Errorf("%q: expected an error", dex)
+		}
+	}
+
+	if err := commandPokedex(client, config, nil, map[string]string{"dex": "2"}); err == nil {
+		t.Errorf("expected an error for --dex without --region")
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// natureCount is the number of natures served by the /nature endpoint
+	natureCount = 25
+	// shinyOdds is the 1-in-n chance of a Pokemon being shiny
+	shinyOdds = 4096
+	// maxIV is the highest individual value of a stat
+	maxIV = 31
+	// maxEV and maxTotalEV cap the effort values of a stat and of a Pokemon
+	maxEV      = 252
+	maxTotalEV = 510
+	// defaultCatchLevel is the level of a caught Pokemon when the area doesn't tell
+	defaultCatchLevel = 5
+	// neutralNature is a nature that raises and lowers no stat
+	neutralNature = "hardy"
+)
+
+// statNames lists the stats in the order the games display them
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// calcHP computes the actual HP stat from the base stat, IV and EV at level
+func calcHP(base, iv, ev, level int) int {
+	return (2*base+iv+ev/4)*level/100 + level + 10
+}
+
+// calcStat computes an actual non-HP stat from the base stat, IV, EV and
+// nature multiplier (0.9, 1 or 1.1) at level
+func calcStat(base, iv, ev, level int, natureMod float64) int {
+	return int(float64((2*base+iv+ev/4)*level/100+5) * natureMod)
+}
+
+// natureModifier returns the multiplier nature applies to stat
+func natureModifier(nature *pokeapi.Nature, stat string) float64 {
+	if nature == nil {
+		return 1
+	}
+
+	increased := nature.IncreasedStat != nil && nature.IncreasedStat.Name == stat
+	decreased := nature.DecreasedStat != nil && nature.DecreasedStat.Name == stat
+	switch {
+	case increased && !decreased:
+		return 1.1
+	case decreased && !increased:
+		return 0.9
+	}
+	return 1
+}
+
+// calcStats computes every actual stat from the base stats of a Pokemon.
+// A nil nature is neutral.
+func calcStats(base []pokeapi.PokemonStat, ivs, evs map[string]int, level int, nature *pokeapi.Nature) map[string]int {
+	stats := make(map[string]int, len(base))
+	for _, s := range base {
+		name := s.Stat.Name
+		if name == "hp" {
+			stats[name] = calcHP(s.BaseStat, ivs[name], evs[name], level)
+			continue
+		}
+		stats[name] = calcStat(s.BaseStat, ivs[name], evs[name], level, natureModifier(nature, name))
+	}
+	return stats
+}
+
+// refreshStats recomputes the actual stats of owned, e.g. after a level-up or EV gain
+func refreshStats(client *pokeapi.Client, owned *pokeapi.OwnedPokemon) error {
+	nature, err := client.GetNature(owned.Nature)
+	if err != nil {
+		return err
+	}
+
+	owned.Stats = calcStats(owned.Pokemon.Stats, owned.IVs, owned.EVs, owned.Level, nature)
+
+	return nil
+}
+
+// rollGender picks a gender from the species gender_rate: the chance of
+// being female in eighths, or -1 for genderless species
+func rollGender(r *rand.Rand, genderRate int) string {
+	switch {
+	case genderRate < 0:
+		return "genderless"
+	case r.Intn(8) < genderRate:
+		return "female"
+	default:
+		return "male"
+	}
+}
+
+// newOwnedPokemon rolls a new instance of pokemon at level: random IVs,
+// nature, gender and shininess, no EVs, and the latest level-up moves
+func newOwnedPokemon(client *pokeapi.Client, r *rand.Rand, pokemon *pokeapi.Pokemon, level int) (*pokeapi.OwnedPokemon, error) {
+	species, err := client.GetPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	growthRate, err := client.GetGrowthRate(species.GrowthRate.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nature, err := client.GetNature(strconv.Itoa(r.Intn(natureCount) + 1))
+	if err != nil {
+		return nil, err
+	}
+
+	moves, err := loadBattleMoves(client, learnableMoves(pokemon, level))
+	if err != nil {
+		return nil, err
+	}
+
+	owned := &pokeapi.OwnedPokemon{
+		Level:      level,
+		Experience: experienceForLevel(growthRate, level),
+		Nature:     nature.Name,
+		Gender:     rollGender(r, species.GenderRate),
+		Shiny:      r.Intn(shinyOdds) == 0,
+		Happiness:  species.BaseHappiness,
+		IVs:        make(map[string]int, len(statNames)),
+		EVs:        make(map[string]int, len(statNames)),
+		Pokemon:    pokemon.Summary(),
+	}
+	for _, stat := range statNames {
+		owned.IVs[stat] = r.Intn(maxIV + 1)
+	}
+	for _, m := range moves {
+		owned.Moves = append(owned.Moves, m.name)
+	}
+	owned.Stats = calcStats(pokemon.Stats, owned.IVs, owned.EVs, level, nature)
+
+	return owned, nil
+}
+
+// addOwnedPokemon gives owned a unique ID, adds it to the caught Pokemon
+// and sends it to the party, or to the PC when the party is full
+func addOwnedPokemon(config *pokeapi.Config, owned *pokeapi.OwnedPokemon) {
+	if config.CaughtPokemon == nil {
+		config.CaughtPokemon = make(map[int]*pokeapi.OwnedPokemon)
+	}
+
+	config.NextPokemonID++
+	owned.ID = config.NextPokemonID
+	config.CaughtPokemon[owned.ID] = owned
+	storePokemon(config, owned.ID)
+}
+
+// sortedOwnedPokemon returns the caught Pokemon ordered by ID
+func sortedOwnedPokemon(config *pokeapi.Config) []*pokeapi.OwnedPokemon {
+	owned := make([]*pokeapi.OwnedPokemon, 0, len(config.CaughtPokemon))
+	for _, p := range config.CaughtPokemon {
+		owned = append(owned, p)
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].ID < owned[j].ID
+	})
+	return owned
+}
+
+// fetchPokemon fetches the Pokemon named by query: a name, or a national
+// dex number written #25
+func fetchPokemon(client *pokeapi.Client, query string) (*pokeapi.Pokemon, error) {
+	if number, isNumber := strings.CutPrefix(query, "#"); isNumber {
+		id, err := strconv.Atoi(number)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%q is not a Pokedex number", query)
+		}
+		return client.GetPokemonByID(id)
+	}
+
+	pokemon, err := client.Catch(query)
+	if err != nil {
+		return nil, withSuggestions(client, "pokemon", query, err)
+	}
+	return pokemon, nil
+}
+
+// findOwnedPokemon returns the caught Pokemon matching query: an ID, a
+// national dex number written #25, a species name or a nickname in any case
+func findOwnedPokemon(config *pokeapi.Config, query string) []*pokeapi.OwnedPokemon {
+	if id, err := strconv.Atoi(query); err == nil {
+		if owned, exists := config.CaughtPokemon[id]; exists {
+			return []*pokeapi.OwnedPokemon{owned}
+		}
+		return nil
+	}
+
+	number := 0
+	if n, isNumber := strings.CutPrefix(query, "#"); isNumber {
+		number, _ = strconv.Atoi(n)
+	}
+
+	var matches []*pokeapi.OwnedPokemon
+	for _, owned := range sortedOwnedPokemon(config) {
+		if owned.Pokemon.Name == query || strings.EqualFold(owned.Nickname, query) || (number > 0 && nationalNumber(owned.Pokemon) == number) {
+			matches = append(matches, owned)
+		}
+	}
+	return matches
+}
+
+// parseOwnedID looks up a caught Pokemon by its ID
+func parseOwnedID(config *pokeapi.Config, param string) (*pokeapi.OwnedPokemon, error) {
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a Pokemon ID. See your Pokemon with: pokedex", param)
+	}
+
+	owned, exists := config.CaughtPokemon[id]
+	if !exists {
+		return nil, fmt.Errorf("you have no Pokemon with ID %d", id)
+	}
+
+	return owned, nil
+}
+
+// awardEVs adds the effort values of the stats of a defeated Pokemon to
+// owned, within the per-stat and total caps. It reports whether any EV was
+// gained.
+func awardEVs(owned *pokeapi.OwnedPokemon, defeated []pokeapi.PokemonStat) bool {
+	if owned.EVs == nil {
+		owned.EVs = make(map[string]int, len(statNames))
+	}
+
+	total := 0
+	for _, ev := range owned.EVs {
+		total += ev
+	}
+
+	gained := false
+	for _, s := range defeated {
+		gain := min(s.Effort, maxEV-owned.EVs[s.Stat.Name], maxTotalEV-total)
+		if gain <= 0 {
+			continue
+		}
+		owned.EVs[s.Stat.Name] += gain
+		total += gain
+		gained = true
+	}
+
+	return gained
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestCalcStats(t *testing.T) {
+	// Garchomp at level 78 with an adamant nature, example from Bulbapedia
+	var pokemon pokeapi.Pokemon
+	bases := map[string]int{"hp": 108, "attack": 130, "defense": 95, "special-attack": 80, "special-defense": 85, "speed": 102}
+	for _, name := range statNames {
+		pokemon.Stats = append(pokemon.Stats, pokeapi.PokemonStat{BaseStat: bases[name], Stat: pokeapi.NamedAPIResource{Name: name}})
+	}
+
+	ivs := map[string]int{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16, "special-defense": 23, "speed": 5}
+	evs := map[string]int{"hp": 74, "attack": 190, "defense": 91, "special-attack": 48, "special-defense": 84, "speed": 23}
+	adamant := &pokeapi.Nature{
+		Name:          "adamant",
+		IncreasedStat: &pokeapi.NamedAPIResource{Name: "attack"},
+		DecreasedStat: &pokeapi.NamedAPIResource{Name: "special-attack"},
+	}
+
+	expected := map[string]int{"hp": 289, "attack": 278, "defense": 193, "special-attack": 135, "special-defense": 171, "speed": 171}
+	stats := calcStats(pokemon.Stats, ivs, evs, 78, adamant)
+	for _, name := range statNames {
+		if stats[name] != expected[name] {
+			t.Errorf("%s: expected %d, got %d", name, expected[name], stats[name])
+		}
+	}
+}
+
+func TestRollGender(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if g := rollGender(r, -1); g != "genderless" {
+			t.Errorf("expected genderless, got %s", g)
+		}
+		if g := rollGender(r, 8); g != "female" {
+			t.Errorf("expected female, got %s", g)
+		}
+		if g := rollGender(r, 0); g != "male" {
+			t.Errorf("expected male, got %s", g)
+		}
+	}
+}
+
+func TestAwardEVs(t *testing.T) {
+	owned := &pokeapi.OwnedPokemon{EVs: map[string]int{"attack": 251, "speed": 200, "hp": 58}}
+
+	defeated := pokeapi.Pokemon{Stats: []pokeapi.PokemonStat{
+		{Effort: 2, Stat: pokeapi.NamedAPIResource{Name: "attack"}},
+		{Effort: 2, Stat: pokeapi.NamedAPIResource{Name: "speed"}},
+	}}
+
+	// Attack is capped at 252 and the total at 510
+	if !awardEVs(owned, defeated.Stats) {
+		t.Fatalf("expected EVs to be gained")
+	}
+	if owned.EVs["attack"] != 252 || owned.EVs["speed"] != 200 {
+		t.Errorf("expected attack 252 and speed 200, got %v", owned.EVs)
+	}
+	if awardEVs(owned, defeated.Stats) {
+		t.Errorf("expected no EVs once the total cap is reached")
+	}
+}
+
+func TestFindOwnedPokemon(t *testing.T) {
+	config := &pokeapi.Config{}
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Pokemon: pokeapi.PokemonSummary{Name: "pidgey"}})
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Pokemon: pokeapi.PokemonSummary{Name: "pidgey"}, Nickname: "birdy"})
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Pokemon: pokeapi.PokemonSummary{Name: "rattata"}})
+
+	if matches := findOwnedPokemon(config, "pidgey"); len(matches) != 2 {
+		t.Errorf("expected 2 pidgey, got %d", len(matches))
+	}
+	if matches := findOwnedPokemon(config, "birdy"); len(matches) != 1 || matches[0].ID != 2 {
+		t.Errorf("expected #2 by nickname, got %v", matches)
+	}
+	if matches := findOwnedPokemon(config, "3"); len(matches) != 1 || matches[0].Pokemon.Name != "rattata" {
+		t.Errorf("expected rattata by ID, got %v", matches)
+	}
+	if matches := findOwnedPokemon(config, "4"); len(matches) != 0 {
+		t.Errorf("expected no match for an unknown ID, got %v", matches)
+	}
+}
+
+func TestFetchPokemonByNumber(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/pokemon/25":      `{"id": 25, "name": "pikachu"}`,
+		"/pokemon/pikachu": `{"id": 25, "name": "pikachu"}`,
+	})
+
+	for _, query := range []string{"#25", "pikachu"} {
+		pokemon, err := fetchPokemon(client, query)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", query, err)
+		}
+		if pokemon.Name != "pikachu" {
+			t.Errorf("%s: expected pikachu, got %s", query, pokemon.Name)
+		}
+	}
+	if _, err := fetchPokemon(client, "#pika"); err == nil {
+		t.Error("expected an error for an invalid number")
+	}
+
+	config := &pokeapi.Config{}
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Nickname: "sparky", Pokemon: pokeapi.PokemonSummary{ID: 25, Name: "pikachu"}})
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Pokemon: pokeapi.PokemonSummary{ID: 16, Name: "pidgey"}})
+	if matches := findOwnedPokemon(config, "#25"); len(matches) != 1 || matches[0].Nickname != "sparky" {
+		t.Errorf("expected #25 to find sparky, got %d matches", len(matches))
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// progressThreshold is the batch size from which a progress indicator is shown
+const progressThreshold = 10
+
+// batchOptions returns the options of a batch of n fetches, showing label
+// and a running count on stderr when the batch is large
+func batchOptions(label string, n int) pokeapi.BatchOptions {
+	if n < progressThreshold {
+		return pokeapi.BatchOptions{}
+	}
+
+	return pokeapi.BatchOptions{
+		OnProgress: func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\r%s %d/%d", label, done, total)
+			if done == total {
+				// Clear the indicator line
+				fmt.Fprint(os.Stderr, "\r\033[K")
+			}
+		},
+	}
+}
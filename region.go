@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+// resourceNames joins the names of resources, e.g. "red-blue, yellow"
+func resourceNames(resources []pokeapi.NamedAPIResource) string {
+	if len(resources) == 0 {
+		return "none"
+	}
+
+	names := make([]string, len(resources))
+	for i, r := range resources {
+		names[i] = r.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// printRegion prints the generation, version groups and Pokedexes of region,
+// and its locations when withLocations is set
+func printRegion(region *pokeapi.Region, withLocations bool) {
+	generation := "unknown generation"
+	if region.MainGeneration != nil {
+		generation = region.MainGeneration.Name
+	}
+
+	fmt.Printf("%s (%s)\n", region.Name, generation)
+	fmt.Printf("  Version groups: %s\n", resourceNames(region.VersionGroups))
+	fmt.Printf("  Pokedexes: %s\n", resourceNames(region.Pokedexes))
+	if !withLocations {
+		fmt.Printf("  Locations: %d\n", len(region.Locations))
+		return
+	}
+
+	fmt.Printf("  Locations (%d):\n", len(region.Locations))
+	for _, location := range region.Locations {
+		fmt.Printf("   - %s\n", location.Name)
+	}
+}
+
+// commandRegions lists every region with its generation, version groups and
+// number of locations, or every location of one region. Usage: regions [region]
+func commandRegions(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	if len(args) == 1 {
+		region, err := client.GetRegion(args[0])
+		if err != nil {
+			return err
+		}
+		printRegion(region, true)
+		return nil
+	}
+
+	var names []string
+	for resource, err := range client.Resources("region", pokeapi.ListOptions{}) {
+		if err != nil {
+			return err
+		}
+		names = append(names, resource.Name)
+	}
+
+	results := client.GetRegionBatch(names, batchOptions("Fetching regions", len(names)))
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+		printRegion(result.Value, false)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const saveFileName = "save.json"
+
+// saveData is the part of the game state written to the save file
+type saveData struct {
+	Pokemon       map[int]*pokeapi.OwnedPokemon `json:"pokemon"`
+	NextPokemonID int                           `json:"next_pokemon_id"`
+	Party         []int                         `json:"party"`
+	Boxes         [][]int                       `json:"boxes"`
+	Seen          map[int]string                `json:"seen"`
+	Caught        map[int]bool                  `json:"caught"`
+	// LegacyCaught is the species-keyed format of older saves, read to migrate them
+	LegacyCaught map[string]pokeapi.Pokemon `json:"caught_pokemon,omitempty"`
+	Inventory    map[string]int             `json:"inventory"`
+	CatchMode    string                     `json:"catch_mode"`
+	Money        int                        `json:"money"`
+	Location     string                     `json:"location"`
+	Version      string                     `json:"version"`
+	Generation   int                        `json:"generation"`
+}
+
+// stateDir returns the directory holding the save file, following the
+// XDG base directory spec ($XDG_STATE_HOME or ~/.local/state)
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "state", "pokedexcli"), nil
+}
+
+// configDir returns the directory holding the configuration, following the
+// XDG base directory spec ($XDG_CONFIG_HOME or ~/.config)
+func configDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "pokedexcli"), nil
+}
+
+// saveFilePath returns the location of the save file
+func saveFilePath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, saveFileName), nil
+}
+
+// saveGame writes the persistent part of config to path
+func saveGame(path string, config *pokeapi.Config) error {
+	data := saveData{
+		Pokemon:       config.CaughtPokemon,
+		NextPokemonID: config.NextPokemonID,
+		Party:         config.Party,
+		Boxes:         config.Boxes,
+		Seen:          config.Seen,
+		Caught:        config.Caught,
+		Inventory:     config.Inventory,
+		CatchMode:     config.CatchMode,
+		Money:         config.Money,
+		Location:      config.Location,
+		Version:       config.Version,
+		Generation:    config.Generation,
+	}
+	return writeJSONFile(path, data)
+}
+
+// loadGame restores config from the save file at path. A missing file
+// starts a new game with the starter inventory and money. The client is
+// only used to migrate the Pokemon of older saves.
+func loadGame(client *pokeapi.Client, path string, config *pokeapi.Config) error {
+	var data saveData
+	err := readJSONFile(path, &data)
+	if errors.Is(err, os.ErrNotExist) {
+		for item, qty := range starterInventory {
+			addItem(config, item, qty)
+		}
+		config.Money = starterMoney
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Species-keyed Pokemon become instances, numbered in name order. They
+	// are migrated first, so a failed lookup leaves config untouched.
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var migrated []*pokeapi.OwnedPokemon
+	for _, name := range slices.Sorted(maps.Keys(data.LegacyCaught)) {
+		pokemon := data.LegacyCaught[name]
+		owned, err := migratedOwnedPokemon(client, r, &pokemon)
+		if err != nil {
+			return fmt.Errorf("migrating %s: %w", name, err)
+		}
+		migrated = append(migrated, owned)
+	}
+
+	config.CaughtPokemon = data.Pokemon
+	config.NextPokemonID = data.NextPokemonID
+	config.Party = data.Party
+	config.Boxes = data.Boxes
+	config.Seen = data.Seen
+	config.Caught = data.Caught
+	for _, owned := range migrated {
+		addOwnedPokemon(config, owned)
+	}
+	// Saves from before the party and PC boxes have Pokemon with no place yet
+	placed := make(map[int]bool)
+	for _, id := range config.Party {
+		placed[id] = true
+	}
+	for _, box := range config.Boxes {
+		for _, id := range box {
+			placed[id] = true
+		}
+	}
+	for _, owned := range sortedOwnedPokemon(config) {
+		if !placed[owned.ID] {
+			storePokemon(config, owned.ID)
+		}
+		// Saves from before the seen/caught Pokedex only know the Pokemon owned
+		registerCaught(config, owned.Pokemon)
+	}
+	config.Inventory = data.Inventory
+	config.CatchMode = data.CatchMode
+	config.Money = data.Money
+	config.Location = data.Location
+	config.Version = data.Version
+	config.Generation = data.Generation
+
+	return nil
+}
+
+// migratedOwnedPokemon turns a Pokemon from an older save into an instance:
+// a neutral nature, no IVs, a gender rolled from the species gender rate
+// and the latest level-up moves
+func migratedOwnedPokemon(client *pokeapi.Client, r *rand.Rand, pokemon *pokeapi.Pokemon) (*pokeapi.OwnedPokemon, error) {
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = pokemon.Name
+	}
+	species, err := client.GetPokemonSpecies(speciesName)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := &pokeapi.OwnedPokemon{
+		Level:     defaultCatchLevel,
+		Nature:    neutralNature,
+		Gender:    rollGender(r, species.GenderRate),
+		Happiness: defaultHappiness,
+		IVs:       make(map[string]int, len(statNames)),
+		EVs:       make(map[string]int, len(statNames)),
+		Pokemon:   pokemon.Summary(),
+	}
+	moves := learnableMoves(pokemon, owned.Level)
+	owned.Moves = moves[:min(len(moves), maxMoves)]
+	owned.Stats = calcStats(pokemon.Stats, owned.IVs, owned.EVs, owned.Level, nil)
+
+	return owned, nil
+}
+
+// writeJSONFile atomically replaces path with the JSON encoding of v
+func writeJSONFile(path string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, body)
+}
+
+// writeFileAtomic replaces path with body, creating its directory if
+// needed, so a crash never leaves a half-written file behind
+func writeFileAtomic(path string, body []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// readJSONFile decodes the JSON file at path into v
+func readJSONFile(path string, v any) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestSaveLoadGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	// A missing save file starts a new game with the starter inventory
+	config := &pokeapi.Config{}
+	if err := loadGame(nil, path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Inventory[defaultBall] != starterInventory[defaultBall] {
+		t.Errorf("expected %d starter balls, got %d", starterInventory[defaultBall], config.Inventory[defaultBall])
+	}
+	if config.Money != starterMoney {
+		t.Errorf("expected ₽%d starter money, got ₽%d", starterMoney, config.Money)
+	}
+	config.Money = 1234
+
+	if err := removeItem(config, defaultBall, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addItem(config, "great-ball", 3)
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Level: 12, Pokemon: pokeapi.PokemonSummary{ID: 25, Name: "pikachu"}})
+	addOwnedPokemon(config, &pokeapi.OwnedPokemon{Level: 7, Pokemon: pokeapi.PokemonSummary{ID: 25, Name: "pikachu"}})
+
+	if err := saveGame(path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded := &pokeapi.Config{}
+	if err := loadGame(nil, path, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Inventory[defaultBall] != starterInventory[defaultBall]-1 {
+		t.Errorf("expected %d balls, got %d", starterInventory[defaultBall]-1, loaded.Inventory[defaultBall])
+	}
+	if loaded.Money != 1234 {
+		t.Errorf("expected ₽1234, got ₽%d", loaded.Money)
+	}
+	if loaded.Inventory["great-ball"] != 3 {
+		t.Errorf("expected 3 great balls, got %d", loaded.Inventory["great-ball"])
+	}
+	if len(loaded.CaughtPokemon) != 2 || loaded.CaughtPokemon[2].Level != 7 {
+		t.Errorf("expected both pikachu to be restored")
+	}
+	if loaded.NextPokemonID != 2 {
+		t.Errorf("expected next ID 2, got %d", loaded.NextPokemonID)
+	}
+}
+
+func TestLoadLegacySave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	legacy := `{"caught_pokemon": {
+		"pidgey": {"id": 16, "name": "pidgey", "stats": [{"base_stat": 40, "stat": {"name": "hp"}}]},
+		"caterpie": {"id": 10, "name": "caterpie"}
+	}}`
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, map[string]string{
+		"/pokemon-species/pidgey":   `{"name": "pidgey", "gender_rate": 4}`,
+		"/pokemon-species/caterpie": `{"name": "caterpie", "gender_rate": -1}`,
+	})
+	config := &pokeapi.Config{}
+	if err := loadGame(client, path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Species-keyed Pokemon become instances, numbered in name order
+	if len(config.CaughtPokemon) != 2 {
+		t.Fatalf("expected 2 migrated Pokemon, got %d", len(config.CaughtPokemon))
+	}
+	if config.CaughtPokemon[1].Pokemon.Name != "caterpie" || config.CaughtPokemon[2].Pokemon.Name != "pidgey" {
+		t.Errorf("unexpected migrated Pokemon: %v, %v", config.CaughtPokemon[1].Pokemon.Name, config.CaughtPokemon[2].Pokemon.Name)
+	}
+	if hp := config.CaughtPokemon[2].Stats["hp"]; hp != calcHP(40, 0, 0, defaultCatchLevel) {
+		t.Errorf("expected migrated stats to be computed, got %d HP", hp)
+	}
+	if gender := config.CaughtPokemon[1].Gender; gender != "genderless" {
+		t.Errorf("expected caterpie to be genderless, got %q", gender)
+	}
+	if gender := config.CaughtPokemon[2].Gender; gender != "male" && gender != "female" {
+		t.Errorf("expected pidgey's gender to be rolled from its species, got %q", gender)
+	}
+
+	// A failed lookup leaves the game as it was, so nothing is saved over the file
+	config = &pokeapi.Config{}
+	if err := loadGame(newTestClient(t, map[string]string{}), path, config); err == nil || len(config.CaughtPokemon) != 0 {
+		t.Errorf("expected the migration to fail without changing the game, got %v", err)
+	}
+}
+
+func TestLoadFullPokemonSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	old := `{"pokemon": {"1": {"id": 1, "level": 5, "pokemon": {
+		"id": 25, "name": "pikachu", "height": 4, "base_experience": 112,
+		"species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		"types": [{"slot": 1, "type": {"name": "electric"}}],
+		"moves": [{"move": {"name": "thunder-shock"}}]
+	}}}, "party": [1]}`
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &pokeapi.Config{}
+	if err := loadGame(nil, path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pikachu := config.CaughtPokemon[1].Pokemon
+	if pikachu.Name != "pikachu" || pikachu.BaseExperience != 112 || nationalNumber(pikachu) != 25 || len(pikachu.Types) != 1 {
+		t.Errorf("expected the summary to be read from the full Pokemon, got %+v", pikachu)
+	}
+
+	// Saving again only writes the summary
+	if err := saveGame(path, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "thunder-shock") || strings.Contains(string(body), "height") {
+		t.Errorf("expected only the summary to be saved, got %s", body)
+	}
+}
+
+func TestRemoveItem(t *testing.T) {
+	config := &pokeapi.Config{}
+	addItem(config, "potion", 1)
+
+	if err := removeItem(config, "potion", 2); err == nil {
+		t.Errorf("expected error when removing more than held")
+	}
+	if err := removeItem(config, "potion", 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, exists := config.Inventory["potion"]; exists {
+		t.Errorf("expected empty stack to be removed from the bag")
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const (
+	// starterMoney is the PokeDollar balance of a new game
+	starterMoney = 3000
+	// maxItemQuantity is the most units of an item the bag holds, as in the
+	// main series
+	maxItemQuantity = 999
+)
+
+// catchReward returns the PokeDollars earned for catching a Pokemon
+func catchReward(baseExperience int) int {
+	return max(2*baseExperience, 10)
+}
+
+// parseItemQuantity splits "great ball 3" into the item name and the
+// quantity (default 1), from 1 to maxItemQuantity
+func parseItemQuantity(args []string) (string, int, error) {
+	qty := 1
+
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[len(args)-1])
+		switch {
+		case errors.Is(err, strconv.ErrRange) || err == nil && n > maxItemQuantity:
+			return "", 0, fmt.Errorf("quantity must be at most %d, got %s", maxItemQuantity, args[len(args)-1])
+		case err == nil && n <= 0:
+			return "", 0, fmt.Errorf("quantity must be positive, got %d", n)
+		case err == nil:
+			qty = n
+			args = args[:len(args)-1]
+		}
+	}
+
+	return normalizeItemName(strings.Join(args, " ")), qty, nil
+}
+
+// itemPrice looks up the cost of a shop item from the /item endpoint
+func itemPrice(client *pokeapi.Client, itemName string) (int, error) {
+	if _, ok := supportedItems[itemName]; !ok {
+		err := fmt.Errorf("the shop doesn't deal in %s", itemName)
+		return 0, suggestAmong(client, "item", itemName, shopItemNames(nil), err)
+	}
+
+	item, err := client.GetItem(itemName)
+	if err != nil {
+		return 0, err
+	}
+
+	return item.Cost, nil
+}
+
+// buyItem pays price*qty and puts the items in the bag
+func buyItem(config *pokeapi.Config, itemName string, price, qty int) error {
+	if price <= 0 {
+		return fmt.Errorf("%s is not for sale", itemName)
+	}
+
+	if config.Inventory[itemName]+qty > maxItemQuantity {
+		return fmt.Errorf("your bag can't hold more than %d %s, you have %d", maxItemQuantity, itemName, config.Inventory[itemName])
+	}
+
+	total := price * qty
+	if config.Money < total {
+		return fmt.Errorf("not enough money: %d x %s costs ₽%d, you have ₽%d", qty, itemName, total, config.Money)
+	}
+
+	config.Money -= total
+	addItem(config, itemName, qty)
+
+	return nil
+}
+
+// sellItem removes qty items from the bag for half their price and returns the earnings
+func sellItem(config *pokeapi.Config, itemName string, price, qty int) (int, error) {
+	if err := removeItem(config, itemName, qty); err != nil {
+		return 0, err
+	}
+
+	earned := price / 2 * qty
+	config.Money += earned
+
+	return earned, nil
+}
+
+// commandShop lists the items for sale with their prices
+func commandShop(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	names := make([]string, 0, len(supportedItems))
+	for name := range supportedItems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := client.GetItemBatch(names, batchOptions("Fetching prices", len(names)))
+
+	fmt.Println("Welcome to the Poke Mart!")
+	for i, name := range names {
+		if results[i].Err != nil {
+			return results[i].Err
+		}
+		price := results[i].Value.Cost
+
+		if price <= 0 {
+			fmt.Printf(" - %-14s not for sale\n", name)
+			continue
+		}
+		fmt.Printf(" - %-14s ₽%d\n", name, price)
+	}
+	fmt.Printf("You have ₽%d\n", config.Money)
+
+	return nil
+}
+
+// commandBuy buys items from the shop. Usage: buy <item> [qty]
+func commandBuy(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	itemName, qty, err := parseItemQuantity(args)
+	if err != nil {
+		return err
+	}
+	if itemName == "" {
+		return fmt.Errorf("item name is required. Usage: %s", commandLists["buy"].usageLine())
+	}
+
+	price, err := itemPrice(client, itemName)
+	if err != nil {
+		return err
+	}
+
+	if err := buyItem(config, itemName, price, qty); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bought %d x %s for ₽%d. You have ₽%d left.\n", qty, itemName, price*qty, config.Money)
+
+	return nil
+}
+
+// commandSell sells items from the bag. Usage: sell <item> [qty]
+func commandSell(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	itemName, qty, err := parseItemQuantity(args)
+	if err != nil {
+		return err
+	}
+	if itemName == "" {
+		return fmt.Errorf("item name is required. Usage: %s", commandLists["sell"].usageLine())
+	}
+
+	price, err := itemPrice(client, itemName)
+	if err != nil {
+		return err
+	}
+
+	earned, err := sellItem(config, itemName, price, qty)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sold %d x %s for ₽%d. You now have ₽%d.\n", qty, itemName, earned, config.Money)
+
+	return nil
+}
+
+// commandMoney prints the PokeDollar balance
+func commandMoney(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	fmt.Printf("You have ₽%d\n", config.Money)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestParseItemQuantity(t *testing.T) {
+	cases := []struct {
+		input    string
+		item     string
+		qty      int
+		hasError bool
+	}{
+		{input: "poke-ball", item: "poke-ball", qty: 1},
+		{input: "great ball 3", item: "great-ball", qty: 3},
+		{input: "potion 0", hasError: true},
+		{input: "potion 999", item: "potion", qty: 999},
+		{input: "potion 1000", hasError: true},
+		{input: "ultra-ball 4611686018427387904", hasError: true},
+		{input: "ultra-ball 99999999999999999999", hasError: true},
+	}
+
+	for _, c := range cases {
+		item, qty, err := parseItemQuantity(strings.Fields(c.input))
+		if c.hasError {
+			if err == nil {
+				t.Errorf("expected error for input '%s'", c.input)
+			}
+			continue
+		}
+		if err != nil || item != c.item || qty != c.qty {
+			t.Errorf("input '%s': got (%s, %d, %v), expected (%s, %d)", c.input, item, qty, err, c.item, c.qty)
+		}
+	}
+}
+
+func TestBuySell(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	client := newTestClient(t, map[string]string{
+		"/item/poke-ball":   `{"name": "poke-ball", "cost": 200}`,
+		"/item/master-ball": `{"name": "master-ball", "cost": 0}`,
+	})
+	config := &pokeapi.Config{Money: 1000}
+
+	if err := commandBuy(client, config, []string{"poke-ball", "3"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Money != 400 || config.Inventory["poke-ball"] != 3 {
+		t.Errorf("expected ₽400 and 3 balls, got ₽%d and %d balls", config.Money, config.Inventory["poke-ball"])
+	}
+
+	if err := commandBuy(client, config, []string{"poke-ball", "3"}, nil); err == nil {
+		t.Errorf("expected error when buying without enough money")
+	}
+	if err := commandBuy(client, config, []string{"poke-ball", "4611686018427387904"}, nil); err == nil || config.Money != 400 {
+		t.Errorf("expected a huge quantity to be refused, got ₽%d and %v", config.Money, err)
+	}
+	if err := buyItem(&pokeapi.Config{Money: 1 << 40, Inventory: map[string]int{"poke-ball": 998}}, "poke-ball", 200, 2); err == nil {
+		t.Errorf("expected error when the bag is full")
+	}
+	if err := commandBuy(client, config, []string{"master-ball"}, nil); err == nil {
+		t.Errorf("expected error when buying an item that is not for sale")
+	}
+	if err := commandBuy(client, config, []string{"rare-candy"}, nil); err == nil {
+		t.Errorf("expected error when buying an item the shop doesn't sell")
+	}
+
+	if err := commandSell(client, config, []string{"poke-ball", "2"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Money != 600 || config.Inventory["poke-ball"] != 1 {
+		t.Errorf("expected ₽600 and 1 ball, got ₽%d and %d balls", config.Money, config.Inventory["poke-ball"])
+	}
+
+	if err := commandSell(client, config, []string{"poke-ball", "2"}, nil); err == nil {
+		t.Errorf("expected error when selling more than held")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Major status conditions, named after the move ailments of the API
+const (
+	statusBurn      = "burn"
+	statusFreeze    = "freeze"
+	statusParalysis = "paralysis"
+	statusPoison    = "poison"
+	statusSleep     = "sleep"
+)
+
+// statusLabels are the short labels shown next to the HP of a Pokemon
+var statusLabels = map[string]string{
+	statusBurn:      "BRN",
+	statusFreeze:    "FRZ",
+	statusParalysis: "PAR",
+	statusPoison:    "PSN",
+	statusSleep:     "SLP",
+}
+
+// statusImmunities lists the types that can't get a status condition
+var statusImmunities = map[string][]string{
+	statusBurn:      {"fire"},
+	statusFreeze:    {"ice"},
+	statusParalysis: {"electric"},
+	statusPoison:    {"poison", "steel"},
+}
+
+const (
+	// fullParalysisChance is the 1-in-n chance of a paralyzed Pokemon not moving
+	fullParalysisChance = 4
+	// thawChance is the percent chance of a frozen Pokemon thawing each turn
+	thawChance = 20
+	// maxSleepTurns is the most turns a Pokemon sleeps for
+	maxSleepTurns = 3
+)
+
+// inflictStatus gives target the status condition move may cause, when
+// the ailment chance rolls, target has none yet and its types allow it
+func (b *battle) inflictStatus(target *battler, move *battleMove) {
+	if _, major := statusLabels[move.ailment]; !major || target.status != "" {
+		return
+	}
+	for _, t := range target.types {
+		if slices.Contains(statusImmunities[move.ailment], t) {
+			return
+		}
+	}
+	if b.r.Intn(100) >= move.ailmentChance {
+		return
+	}
+
+	target.status = move.ailment
+	if target.status == statusSleep {
+		target.sleepTurns = 1 + b.r.Intn(maxSleepTurns)
+	}
+
+	switch target.status {
+	case statusBurn:
+		fmt.Printf("%s was burned!\n", target.name())
+	case statusFreeze:
+		fmt.Printf("%s was frozen solid!\n", target.name())
+	case statusParalysis:
+		fmt.Printf("%s is paralyzed! It may be unable to move!\n", target.name())
+	case statusPoison:
+		fmt.Printf("%s was poisoned!\n", target.name())
+	case statusSleep:
+		fmt.Printf("%s fell asleep!\n", target.name())
+	}
+}
+
+// canMove reports whether attacker's status lets it move this turn,
+// waking it up or thawing it out when the condition ends
+func (b *battle) canMove(attacker *battler) bool {
+	switch attacker.status {
+	case statusSleep:
+		if attacker.sleepTurns > 0 {
+			attacker.sleepTurns--
+			fmt.Printf("%s is fast asleep.\n", attacker.name())
+			return false
+		}
+		attacker.status = ""
+		fmt.Printf("%s woke up!\n", attacker.name())
+	case statusFreeze:
+		if b.r.Intn(100) >= thawChance {
+			fmt.Printf("%s is frozen solid!\n", attacker.name())
+			return false
+		}
+		attacker.status = ""
+		fmt.Printf("%s thawed out!\n", attacker.name())
+	case statusParalysis:
+		if b.r.Intn(fullParalysisChance) == 0 {
+			fmt.Printf("%s is paralyzed! It can't move!\n", attacker.name())
+			return false
+		}
+	}
+	return true
+}
+
+// statusDamage hurts target by its burn or poison at the end of a turn
+func statusDamage(target *battler) {
+	var damage int
+	switch target.status {
+	case statusBurn:
+		damage = target.maxHP / 16
+		fmt.Printf("%s is hurt by its burn!\n", target.name())
+	case statusPoison:
+		damage = target.maxHP / 8
+		fmt.Printf("%s is hurt by poison!\n", target.name())
+	default:
+		return
+	}
+
+	target.hp = max(target.hp-max(damage, 1), 0)
+	fmt.Printf("%s\n", target)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+func TestInflictStatus(t *testing.T) {
+	b := &battle{r: rand.New(rand.NewSource(1))}
+	thunderShock := &battleMove{name: "thunder-shock", ailment: statusParalysis, ailmentChance: 100}
+
+	pidgey := &battler{owned: &pokeapi.OwnedPokemon{}, types: []string{"normal", "flying"}}
+	b.inflictStatus(pidgey, thunderShock)
+	if pidgey.status != statusParalysis {
+		t.Errorf("expected pidgey to be paralyzed, got %q", pidgey.status)
+	}
+
+	// A status condition isn't replaced by another one
+	b.inflictStatus(pidgey, &battleMove{ailment: statusBurn, ailmentChance: 100})
+	if pidgey.status != statusParalysis {
+		t.Errorf("expected pidgey to stay paralyzed, got %q", pidgey.status)
+	}
+
+	pikachu := &battler{owned: &pokeapi.OwnedPokemon{}, types: []string{"electric"}}
+	b.inflictStatus(pikachu, thunderShock)
+	if pikachu.status != "" {
+		t.Errorf("expected electric types not to be paralyzed, got %q", pikachu.status)
+	}
+
+	rattata := &battler{owned: &pokeapi.OwnedPokemon{}, types: []string{"normal"}}
+	for _, move := range []*battleMove{{ailment: statusParalysis}, {ailment: "confusion", ailmentChance: 100}} {
+		b.inflictStatus(rattata, move)
+	}
+	if rattata.status != "" {
+		t.Errorf("expected no status from a 0%% chance or a minor ailment, got %q", rattata.status)
+	}
+}
+
+func TestCanMoveAsleep(t *testing.T) {
+	b := &battle{r: rand.New(rand.NewSource(1))}
+	sleeper := &battler{owned: &pokeapi.OwnedPokemon{}, status: statusSleep, sleepTurns: 2}
+
+	for turn := 1; turn <= 2; turn++ {
+		if b.canMove(sleeper) {
+			t.Fatalf("expected the Pokemon to sleep on turn %d", turn)
+		}
+	}
+	if !b.canMove(sleeper) || sleeper.status != "" {
+		t.Errorf("expected the Pokemon to wake up, got status %q", sleeper.status)
+	}
+}
+
+func TestStatusEffects(t *testing.T) {
+	poisoned := &battler{owned: &pokeapi.OwnedPokemon{}, hp: 80, maxHP: 80, status: statusPoison}
+	statusDamage(poisoned)
+	if poisoned.hp != 70 {
+		t.Errorf("expected poison to take 1/8 of the max HP, got %d HP left", poisoned.hp)
+	}
+
+	paralyzed := &battler{owned: &pokeapi.OwnedPokemon{Stats: map[string]int{"speed": 90}}, status: statusParalysis}
+	if speed := paralyzed.speed(); speed != 45 {
+		t.Errorf("expected paralysis to halve the speed, got %d", speed)
+	}
+
+	attacker := &battler{owned: &pokeapi.OwnedPokemon{Level: 50, Stats: map[string]int{"attack": 100}}}
+	defender := &battler{owned: &pokeapi.OwnedPokemon{Level: 50, Stats: map[string]int{"defense": 100}}}
+	tackle := &battleMove{name: "tackle", moveType: "normal", damageClass: "physical", power: 40}
+	healthy, _ := calcDamage(rand.New(rand.NewSource(1)), attacker, defender, tackle, 1)
+	attacker.status = statusBurn
+	burned, _ := calcDamage(rand.New(rand.NewSource(1)), attacker, defender, tackle, 1)
+	if burned >= healthy {
+		t.Errorf("expected a burn to lower physical damage, got %d burned and %d healthy", burned, healthy)
+	}
+}
+
+func TestRunParalyzed(t *testing.T) {
+	t.Cleanup(func() { currentBattle = nil })
+
+	// A paralyzed Pokemon is slower than the foe, so it doesn't always get away
+	escapes := 0
+	for seed := int64(1); seed <= 20; seed++ {
+		player := &battler{owned: &pokeapi.OwnedPokemon{Stats: map[string]int{"speed": 100}}, hp: 50, maxHP: 50, status: statusParalysis}
+		foe := &battler{owned: &pokeapi.OwnedPokemon{Level: 1, Stats: map[string]int{"speed": 60}}, hp: 50, maxHP: 50}
+		currentBattle = &battle{r: rand.New(rand.NewSource(seed)), team: []*battler{player}, foe: foe, isWild: true}
+		if err := commandRun(nil, &pokeapi.Config{}, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if currentBattle == nil {
+			escapes++
+		}
+	}
+	if escapes == 20 {
+		t.Errorf("expected paralysis to lower the escape odds")
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pannipasra/pokedexcli/internals/pokeapi"
+)
+
+const typeChartFileName = "typecharts.json"
+
+// allTypes lists every type that takes part in the type chart
+var allTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// typeChart maps an attacking type to the multiplier it deals to each
+// defending type. Pairs that are missing are neutral (x1).
+type typeChart map[string]map[string]float64
+
+// loadedTypeCharts holds one type chart per generation once loaded from disk or PokeAPI
+var loadedTypeCharts map[int]typeChart
+
+// effectiveness returns the damage multiplier of an attackType move against
+// a Pokemon with defenderTypes (one or two types)
+func (c typeChart) effectiveness(attackType string, defenderTypes ...string) float64 {
+	multiplier := 1.0
+	for _, defender := range defenderTypes {
+		if m, ok := c[attackType][defender]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// buildTypeCharts fetches the damage relations of every type from PokeAPI
+// and builds the type chart of each generation
+func buildTypeCharts(client *pokeapi.Client) (map[int]typeChart, error) {
+	types := make([]*pokeapi.Type, 0, len(allTypes))
+	for _, result := range client.GetTypeBatch(allTypes, batchOptions("Fetching types", len(allTypes))) {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		types = append(types, result.Value)
+	}
+
+	charts := make(map[int]typeChart, latestGeneration)
+	for gen := 1; gen <= latestGeneration; gen++ {
+		charts[gen] = typeChartForGeneration(types, gen)
+	}
+
+	return charts, nil
+}
+
+// typeChartForGeneration builds the chart of gen: types introduced later are
+// left out, and past damage relations (valid up to and including their
+// generation) replace the current ones
+func typeChartForGeneration(types []*pokeapi.Type, gen int) typeChart {
+	chart := make(typeChart, len(types))
+	for _, t := range types {
+		if generationNumber(t.Generation.Name) > gen {
+			continue
+		}
+
+		relations := t.DamageRelations
+		best := 0
+		for _, past := range t.PastDamageRelations {
+			pastGen := generationNumber(past.Generation.Name)
+			if pastGen >= gen && (best == 0 || pastGen < best) {
+				best, relations = pastGen, past.DamageRelations
+			}
+		}
+		chart[t.Name] = damageRelationsRow(relations)
+	}
+
+	for _, row := range chart {
+		for defender := range row {
+			if _, exists := chart[defender]; !exists {
+				delete(row, defender)
+			}
+		}
+	}
+
+	return chart
+}
+
+// damageRelationsRow turns the damage_relations of an attacking type into a chart row
+func damageRelationsRow(relations pokeapi.TypeDamageRelations) map[string]float64 {
+	row := make(map[string]float64)
+	for _, t := range relations.DoubleDamageTo {
+		row[t.Name] = 2
+	}
+	for _, t := range relations.HalfDamageTo {
+		row[t.Name] = 0.5
+	}
+	for _, t := range relations.NoDamageTo {
+		row[t.Name] = 0
+	}
+	return row
+}
+
+// getTypeChart returns the type chart of gen, reading the charts from the
+// on-disk cache so they work offline, and building them from PokeAPI the first time
+func getTypeChart(client *pokeapi.Client, gen int) (typeChart, error) {
+	if loadedTypeCharts == nil {
+		dir, err := stateDir()
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, typeChartFileName)
+
+		var charts map[int]typeChart
+		err = readJSONFile(path, &charts)
+		if errors.Is(err, os.ErrNotExist) {
+			charts, err = buildTypeCharts(client)
+			if err != nil {
+				return nil, err
+			}
+			if err := writeJSONFile(path, charts); err != nil {
+				return nil, err
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		loadedTypeCharts = charts
+	}
+
+	chart, ok := loadedTypeCharts[gen]
+	if !ok {
+		return nil, fmt.Errorf("no type chart for generation %d", gen)
+	}
+
+	return chart, nil
+}
+
+// typeEffectiveness returns the damage multiplier in gen of an attackType move against defenderTypes
+func typeEffectiveness(client *pokeapi.Client, gen int, attackType string, defenderTypes []string) (float64, error) {
+	if attackType == struggle.moveType {
+		return 1, nil
+	}
+
+	chart, err := getTypeChart(client, gen)
+	if err != nil {
+		return 0, err
+	}
+
+	return chart.effectiveness(attackType, defenderTypes...), nil
+}
+
+// isTypeName reports whether name is one of the types of the chart
+func isTypeName(name string) bool {
+	for _, t := range allTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatMultipliers prints "fire x2, rock x4" sorted by strongest multiplier first
+func formatMultipliers(multipliers map[string]float64) string {
+	if len(multipliers) == 0 {
+		return "none"
+	}
+
+	names := make([]string, 0, len(multipliers))
+	for name := range multipliers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if multipliers[names[i]] != multipliers[names[j]] {
+			return multipliers[names[i]] > multipliers[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s x%g", name, multipliers[name])
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// commandMatchup prints how an attacking type (or each type of a Pokemon)
+// fares against a defending Pokemon, followed by the defender's weaknesses,
+// resistances and immunities, in the selected generation. Usage: matchup <attacker-type|pokemon|#number> <defender-pokemon|#number>
+func commandMatchup(client *pokeapi.Client, config *pokeapi.Config, args []string, flags map[string]string) error {
+	gen := activeGeneration(config)
+	chart, err := getTypeChart(client, gen)
+	if err != nil {
+		return err
+	}
+
+	attackTypes := []string{args[0]}
+	if !isTypeName(args[0]) {
+		attacker, err := fetchPokemon(client, args[0])
+		if err != nil {
+			return err
+		}
+		attackTypes = typesForGeneration(attacker.Summary(), gen)
+	} else if _, exists := chart[args[0]]; !exists {
+		return fmt.Errorf("the %s type doesn't exist in generation %d", args[0], gen)
+	}
+
+	defender, err := fetchPokemon(client, args[1])
+	if err != nil {
+		return err
+	}
+	defenderTypes := typesForGeneration(defender.Summary(), gen)
+
+	fmt.Printf("%s (%s) in generation %d\n", defender.Name, strings.Join(defenderTypes, "/"), gen)
+	for _, attackType := range attackTypes {
+		fmt.Printf(" - %s attacks: x%g\n", attackType, chart.effectiveness(attackType, defenderTypes...))
+	}
+
+	weaknesses := make(map[string]float64)
+	resistances := make(map[string]float64)
+	var immunities []string
+	for _, attackType := range allTypes {
+		if _, exists := chart[attackType]; !exists {
+			continue
+		}
+		switch m := chart.effectiveness(attackType, defenderTypes...); {
+		case m == 0:
+			immunities = append(immunities, attackType)
+		case m > 1:
+			weaknesses[attackType] = m
+		case m < 1:
+			resistances[attackType] = m
+		}
+	}
+	if len(immunities) == 0 {
+		immunities = []string{"none"}
+	}
+
+	fmt.Printf("Weaknesses: %s\n", formatMultipliers(weaknesses))
+	fmt.Printf("Resistances: %s\n", formatMultipliers(resistances))
+	fmt.Printf("Immunities: %s\n", strings.Join(immunities, ", "))
+
+	return nil
+}
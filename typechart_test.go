@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuildTypeCharts(t *testing.T) {
+	routes := make(map[string]string)
+	for _, name := range allTypes {
+		routes["/type/"+name] = fmt.Sprintf(`{"name": %q, "generation": {"name": "generation-i"}, "damage_relations": {}}`, name)
+	}
+	routes["/type/electric"] = `{"name": "electric", "generation": {"name": "generation-i"}, "damage_relations": {
+		"double_damage_to": [{"name": "water"}, {"name": "flying"}],
+		"half_damage_to": [{"name": "grass"}, {"name": "electric"}, {"name": "dragon"}],
+		"no_damage_to": [{"name": "ground"}]
+	}}`
+	routes["/type/ghost"] = `{"name": "ghost", "generation": {"name": "generation-i"},
+		"damage_relations": {"half_damage_to": [{"name": "dark"}]},
+		"past_damage_relations": [{"generation": {"name": "generation-v"}, "damage_relations": {
+			"half_damage_to": [{"name": "dark"}, {"name": "steel"}]
+		}}]
+	}`
+	routes["/type/dark"] = `{"name": "dark", "generation": {"name": "generation-ii"}, "damage_relations": {}}`
+	routes["/type/steel"] = `{"name": "steel", "generation": {"name": "generation-ii"}, "damage_relations": {}}`
+	client := newTestClient(t, routes)
+
+	charts, err := buildTypeCharts(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chart := charts[latestGeneration]
+
+	cases := []struct {
+		defender []string
+		expected float64
+	}{
+		{defender: []string{"normal"}, expected: 1},
+		{defender: []string{"water"}, expected: 2},
+		{defender: []string{"water", "flying"}, expected: 4},
+		{defender: []string{"water", "grass"}, expected: 1},
+		{defender: []string{"grass", "dragon"}, expected: 0.25},
+		{defender: []string{"water", "ground"}, expected: 0},
+	}
+
+	for _, c := range cases {
+		if actual := chart.effectiveness("electric", c.defender...); actual != c.expected {
+			t.Errorf("electric vs %v: expected %v, got %v", c.defender, c.expected, actual)
+		}
+	}
+	if actual := chart.effectiveness("fire", "water"); actual != 1 {
+		t.Errorf("expected neutral multiplier for an empty row, got %v", actual)
+	}
+
+	// Steel resisted ghost up to generation 5
+	if actual := charts[5].effectiveness("ghost", "steel"); actual != 0.5 {
+		t.Errorf("expected ghost vs steel x0.5 in generation 5, got %v", actual)
+	}
+	if actual := charts[6].effectiveness("ghost", "steel"); actual != 1 {
+		t.Errorf("expected ghost vs steel x1 in generation 6, got %v", actual)
+	}
+
+	// Dark and steel were introduced in generation 2
+	if _, exists := charts[1]["dark"]; exists {
+		t.Errorf("expected no dark type in generation 1")
+	}
+	if _, exists := charts[1]["ghost"]["dark"]; exists {
+		t.Errorf("expected no ghost vs dark relation in generation 1")
+	}
+}